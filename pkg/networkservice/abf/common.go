@@ -0,0 +1,301 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abf
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/networkservicemesh/govpp/binapi/abf"
+	"github.com/networkservicemesh/govpp/binapi/acl"
+	"github.com/networkservicemesh/govpp/binapi/acl_types"
+	"github.com/networkservicemesh/govpp/binapi/fib_types"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+const (
+	abfTag = "nsm-abf"
+)
+
+// connInfo - the interfaces of the connection: ingress from the client and egress to the endpoint
+type connInfo struct {
+	conn    *networkservice.Connection
+	ingress interface_types.InterfaceIndex
+	egress  interface_types.InterfaceIndex
+}
+
+type abfPolicy struct {
+	id    uint32
+	isIP6 bool
+	paths []fib_types.FibPath
+}
+
+// binding - the policy attached to the ingress of the source connection forwarding to the egress of the target one
+type binding struct {
+	sourceID  string
+	targetID  string
+	swIfIndex interface_types.InterfaceIndex
+	priority  uint32
+	aclIndex  uint32
+	policies  []*abfPolicy
+}
+
+type abfTable struct {
+	policies     []*Policy
+	conns        map[string]*connInfo
+	bindings     map[string]*binding
+	nextPolicyID uint32
+	mu           sync.Mutex
+}
+
+func newTable(policies []*Policy) *abfTable {
+	return &abfTable{
+		policies: policies,
+		conns:    make(map[string]*connInfo),
+		bindings: make(map[string]*binding),
+	}
+}
+
+// add - stores the connection and binds the policies it is the source or the target of
+func (t *abfTable) add(ctx context.Context, vppConn api.Connection, info *connInfo) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Rebind the policies if the connection interfaces have changed on refresh
+	if prev, ok := t.conns[info.conn.GetId()]; ok && (prev.ingress != info.ingress || prev.egress != info.egress) {
+		if err := t.unbindAll(ctx, vppConn, info.conn.GetId()); err != nil {
+			return err
+		}
+	}
+	t.conns[info.conn.GetId()] = info
+	for i, p := range t.policies {
+		if p.Target.isEmpty() {
+			continue
+		}
+		for _, other := range t.conns {
+			if other.conn.GetId() == info.conn.GetId() {
+				continue
+			}
+			if p.Source.matches(info.conn) && p.Target.matches(other.conn) {
+				if err := t.bind(ctx, vppConn, i, info, other); err != nil {
+					return err
+				}
+			}
+			if p.Source.matches(other.conn) && p.Target.matches(info.conn) {
+				if err := t.bind(ctx, vppConn, i, other, info); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// remove - removes the connection and all the policies it is the source or the target of
+func (t *abfTable) remove(ctx context.Context, vppConn api.Connection, connID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.conns, connID)
+	return t.unbindAll(ctx, vppConn, connID)
+}
+
+func (t *abfTable) unbindAll(ctx context.Context, vppConn api.Connection, connID string) error {
+	var err error
+	for key, b := range t.bindings {
+		if b.sourceID != connID && b.targetID != connID {
+			continue
+		}
+		if unbindErr := unbind(ctx, vppConn, b); unbindErr != nil {
+			err = multierror.Append(err, unbindErr)
+		}
+		delete(t.bindings, key)
+	}
+	return err
+}
+
+func (t *abfTable) bind(ctx context.Context, vppConn api.Connection, policyIndex int, source, target *connInfo) error {
+	key := fmt.Sprintf("%d/%s/%s", policyIndex, source.conn.GetId(), target.conn.GetId())
+	if _, ok := t.bindings[key]; ok {
+		return nil
+	}
+	p := t.policies[policyIndex]
+
+	aclIndex, err := addACL(ctx, vppConn, fmt.Sprintf("%s-%s", abfTag, p.Name), p)
+	if err != nil {
+		return err
+	}
+	b := &binding{
+		sourceID:  source.conn.GetId(),
+		targetID:  target.conn.GetId(),
+		swIfIndex: source.ingress,
+		priority:  p.Priority,
+		aclIndex:  aclIndex,
+	}
+	// The binding is stored before it is complete to be removed on close if the creation fails
+	t.bindings[key] = b
+
+	for _, nh := range nextHops(target.conn) {
+		policy := &abfPolicy{
+			id:    t.nextPolicyID,
+			isIP6: nh.IP.To4() == nil,
+			paths: []fib_types.FibPath{
+				{
+					SwIfIndex: uint32(target.egress),
+					Weight:    1,
+					Proto:     types.IsV6toFibProto(nh.IP.To4() == nil),
+					Nh: fib_types.FibPathNh{
+						Address: types.ToVppAddress(nh.IP).Un,
+					},
+				},
+			},
+		}
+		t.nextPolicyID++
+		if err := policyAddDel(ctx, vppConn, policy, aclIndex, true); err != nil {
+			return err
+		}
+		if err := attachAddDel(ctx, vppConn, b, policy, true); err != nil {
+			// The policy isn't a part of the binding yet, so it is deleted here
+			if delErr := policyAddDel(ctx, vppConn, policy, aclIndex, false); delErr != nil {
+				err = multierror.Append(err, delErr)
+			}
+			return err
+		}
+		b.policies = append(b.policies, policy)
+	}
+	return nil
+}
+
+// unbind - detaches and deletes all the policies of the binding and deletes its ACL. It doesn't stop on errors,
+// so that as much as possible is removed
+func unbind(ctx context.Context, vppConn api.Connection, b *binding) error {
+	var err error
+	for _, policy := range b.policies {
+		if detachErr := attachAddDel(ctx, vppConn, b, policy, false); detachErr != nil {
+			err = multierror.Append(err, detachErr)
+		}
+		if delErr := policyAddDel(ctx, vppConn, policy, b.aclIndex, false); delErr != nil {
+			err = multierror.Append(err, delErr)
+		}
+	}
+	now := time.Now()
+	if _, aclErr := acl.NewServiceClient(vppConn).ACLDel(ctx, &acl.ACLDel{ACLIndex: b.aclIndex}); aclErr != nil {
+		return multierror.Append(err, errors.Wrap(aclErr, "vppapi ACLDel returned error"))
+	}
+	log.FromContext(ctx).
+		WithField("aclIndex", b.aclIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "ACLDel").Debug("completed")
+	return err
+}
+
+// nextHops - returns the first destination address of the target connection of each IP family
+func nextHops(conn *networkservice.Connection) []*net.IPNet {
+	var rv []*net.IPNet
+	var hasIP4, hasIP6 bool
+	for _, nh := range conn.GetContext().GetIpContext().GetDstIPNets() {
+		if nh == nil {
+			continue
+		}
+		isIP6 := nh.IP.To4() == nil
+		if (isIP6 && hasIP6) || (!isIP6 && hasIP4) {
+			continue
+		}
+		hasIP4, hasIP6 = hasIP4 || !isIP6, hasIP6 || isIP6
+		rv = append(rv, nh)
+	}
+	return rv
+}
+
+func addACL(ctx context.Context, vppConn api.Connection, tag string, p *Policy) (uint32, error) {
+	rules := make([]acl_types.ACLRule, len(p.Rules))
+	copy(rules, p.Rules)
+
+	now := time.Now()
+	rsp, err := acl.NewServiceClient(vppConn).ACLAddReplace(ctx, &acl.ACLAddReplace{
+		ACLIndex: ^uint32(0),
+		Tag:      tag,
+		Count:    uint32(len(rules)),
+		R:        rules,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "vppapi ACLAddReplace returned error")
+	}
+	log.FromContext(ctx).
+		WithField("aclIndex", rsp.ACLIndex).
+		WithField("tag", tag).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "ACLAddReplace").Debug("completed")
+	return rsp.ACLIndex, nil
+}
+
+func policyAddDel(ctx context.Context, vppConn api.Connection, policy *abfPolicy, aclIndex uint32, isAdd bool) error {
+	now := time.Now()
+	if _, err := abf.NewServiceClient(vppConn).AbfPolicyAddDel(ctx, &abf.AbfPolicyAddDel{
+		IsAdd: isAdd,
+		Policy: abf.AbfPolicy{
+			PolicyID: policy.id,
+			ACLIndex: aclIndex,
+			NPaths:   uint8(len(policy.paths)),
+			Paths:    policy.paths,
+		},
+	}); err != nil {
+		return errors.Wrap(err, "vppapi AbfPolicyAddDel returned error")
+	}
+	log.FromContext(ctx).
+		WithField("policyID", policy.id).
+		WithField("aclIndex", aclIndex).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "AbfPolicyAddDel").Debug("completed")
+	return nil
+}
+
+func attachAddDel(ctx context.Context, vppConn api.Connection, b *binding, policy *abfPolicy, isAdd bool) error {
+	now := time.Now()
+	if _, err := abf.NewServiceClient(vppConn).AbfItfAttachAddDel(ctx, &abf.AbfItfAttachAddDel{
+		IsAdd: isAdd,
+		Attach: abf.AbfItfAttach{
+			PolicyID:  policy.id,
+			SwIfIndex: b.swIfIndex,
+			Priority:  b.priority,
+			IsIPv6:    policy.isIP6,
+		},
+	}); err != nil {
+		return errors.Wrap(err, "vppapi AbfItfAttachAddDel returned error")
+	}
+	log.FromContext(ctx).
+		WithField("policyID", policy.id).
+		WithField("swIfIndex", b.swIfIndex).
+		WithField("isIPv6", policy.isIP6).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "AbfItfAttachAddDel").Debug("completed")
+	return nil
+}
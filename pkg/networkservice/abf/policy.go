@@ -0,0 +1,69 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abf
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/govpp/binapi/acl_types"
+)
+
+// Selector selects the connections by the connection ID and/or by the labels
+type Selector struct {
+	// ID - ID of any path segment of the connection
+	ID string
+	// Labels - labels the connection must have
+	Labels map[string]string
+}
+
+func (s *Selector) isEmpty() bool {
+	return s.ID == "" && len(s.Labels) == 0
+}
+
+func (s *Selector) matches(conn *networkservice.Connection) bool {
+	if s.ID != "" {
+		found := false
+		for _, segment := range conn.GetPath().GetPathSegments() {
+			if segment.GetId() == s.ID {
+				found = true
+				break
+			}
+		}
+		if !found && conn.GetId() != s.ID {
+			return false
+		}
+	}
+	for k, v := range s.Labels {
+		if label, ok := conn.GetLabels()[k]; !ok || label != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Policy steers the traffic of the source connections matching the rules into the target connection
+type Policy struct {
+	// Name - name of the policy, used as the tag of its ACLs
+	Name string
+	// Rules - ACL rules selecting the traffic, the permit rules are forwarded to the target
+	Rules []acl_types.ACLRule
+	// Source - the connections the policy is attached to. Empty selector matches all connections
+	Source Selector
+	// Target - the connection the traffic is forwarded to. Policy with empty target is never applied
+	Target Selector
+	// Priority - priority of the policy on the source interface, lower value is matched first
+	Priority uint32
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abf
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+type abfServer struct {
+	vppConn api.Connection
+	table   *abfTable
+}
+
+// NewServer creates a NetworkServiceServer chain element steering the traffic of the connection matching the policy
+// rules into the policy target connection. The policies are removed when either of the connections is closed
+func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &abfServer{
+		vppConn: vppConn,
+		table:   newTable(o.policies),
+	}
+}
+
+func (a *abfServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	ingress, ok := ifindex.Load(ctx, metadata.IsClient(a))
+	if !ok {
+		return conn, nil
+	}
+	egress, ok := ifindex.Load(ctx, true)
+	if !ok {
+		return conn, nil
+	}
+
+	if err := a.table.add(ctx, a.vppConn, &connInfo{
+		conn:    conn.Clone(),
+		ingress: ingress,
+		egress:  egress,
+	}); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := a.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (a *abfServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if err := a.table.remove(ctx, a.vppConn, conn.GetId()); err != nil {
+		log.FromContext(ctx).WithField("abf", "server").Errorf("failed to remove the ABF policies: %s", err.Error())
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -28,8 +28,20 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/cleanup"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/abf"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/ipsec/staticsa"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/memif"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/srv6"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vhostuser"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vlan"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vxlan"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/metrics"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/rxmode"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/rxplacement"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/twicenat"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/l2bridgedomain"
 )
 
 type forwarderOptions struct {
@@ -39,10 +51,24 @@ type forwarderOptions struct {
 	clientURL                        *url.URL
 	dialTimeout                      time.Duration
 	domain2Device                    map[string]string
+	vlanOpts                         []vlan.Option
+	l2BridgeDomainOpts               []l2bridgedomain.Option
+	abfOpts                          []abf.Option
+	twiceNATOpts                     []twicenat.Option
 	mechanismPrioriyList             []string
 	metricsOpts                      []metrics.Option
 	cleanupOpts                      []cleanup.Option
 	vxlanOpts                        []vxlan.Option
+	memifOpts                        []memif.Option
+	vhostUserOpts                    []vhostuser.Option
+	kernelOpts                       []kernel.Option
+	rxModeOpts                       []rxmode.Option
+	rxPlacementOpts                  []rxplacement.Option
+	ipsecOpts                        []ipsec.Option
+	ipsecKeyDir                      string
+	ipsecStaticSA                    bool
+	ipsecStaticSAOpts                []staticsa.Option
+	srv6Opts                         []srv6.Option
 	dialOpts                         []grpc.DialOption
 	clientAdditionalFunctionality    []networkservice.NetworkServiceClient
 }
@@ -91,10 +117,35 @@ func WithDialTimeout(dialTimeout time.Duration) Option {
 	}
 }
 
-// WithVlanDomain2Device sets vlan option
-func WithVlanDomain2Device(domain2Device map[string]string) Option {
+// WithVlanDomain2Device sets vlan option. The vlan options configure the sub-interfaces per vlan domain, e.g. QinQ
+func WithVlanDomain2Device(domain2Device map[string]string, opts ...vlan.Option) Option {
 	return func(o *forwarderOptions) {
 		o.domain2Device = domain2Device
+		o.vlanOpts = opts
+	}
+}
+
+// WithL2BridgeDomainOptions sets the bridge domain behaviour of the vlan connections, e.g. flooding, MAC aging and
+// ARP termination per network service or vlan domain
+func WithL2BridgeDomainOptions(opts ...l2bridgedomain.Option) Option {
+	return func(o *forwarderOptions) {
+		o.l2BridgeDomainOpts = opts
+	}
+}
+
+// WithABFOptions sets the ACL based forwarding policies steering the traffic of the connections into other
+// connections, e.g. through a firewall
+func WithABFOptions(opts ...abf.Option) Option {
+	return func(o *forwarderOptions) {
+		o.abfOpts = opts
+	}
+}
+
+// WithTwiceNATOptions sets the pool the clients of the network services with overlapping address spaces get their
+// unique addresses from
+func WithTwiceNATOptions(opts ...twicenat.Option) Option {
+	return func(o *forwarderOptions) {
+		o.twiceNATOpts = opts
 	}
 }
 
@@ -126,6 +177,72 @@ func WithVxlanOptions(opts ...vxlan.Option) Option {
 	}
 }
 
+// WithVhostUserOptions sets vhost-user options, e.g. the socket mode, the socket dir and the features
+func WithVhostUserOptions(opts ...vhostuser.Option) Option {
+	return func(o *forwarderOptions) {
+		o.vhostUserOpts = opts
+	}
+}
+
+// WithSRv6Options enables the srv6 remote mechanism. srv6.WithLocator is required
+func WithSRv6Options(opts ...srv6.Option) Option {
+	return func(o *forwarderOptions) {
+		o.srv6Opts = opts
+	}
+}
+
+// WithKernelOptions sets kernel mechanism options, e.g. the default kernel interface backend, the tap tuning and the offload profiles
+func WithKernelOptions(opts ...kernel.Option) Option {
+	return func(o *forwarderOptions) {
+		o.kernelOpts = opts
+	}
+}
+
+// WithMemifOptions sets memif options, e.g. the queues, the ring and buffer sizes and the secrets
+func WithMemifOptions(opts ...memif.Option) Option {
+	return func(o *forwarderOptions) {
+		o.memifOpts = opts
+	}
+}
+
+// WithRxModeOptions sets rx mode options, e.g. the default rx mode and the rx modes of the network services
+func WithRxModeOptions(opts ...rxmode.Option) Option {
+	return func(o *forwarderOptions) {
+		o.rxModeOpts = opts
+	}
+}
+
+// WithRxPlacementOptions sets rx placement options, e.g. the strategy of assigning the rx queues to VPP workers
+func WithRxPlacementOptions(opts ...rxplacement.Option) Option {
+	return func(o *forwarderOptions) {
+		o.rxPlacementOpts = opts
+	}
+}
+
+// WithIPSecOptions sets ipsec options
+func WithIPSecOptions(opts ...ipsec.Option) Option {
+	return func(o *forwarderOptions) {
+		o.ipsecOpts = opts
+	}
+}
+
+// WithIPSecKeyDir sets the directory the ipsec key material is passed to VPP through.
+// Leftovers in the directory are removed at the forwarder startup.
+func WithIPSecKeyDir(dir string) Option {
+	return func(o *forwarderOptions) {
+		o.ipsecKeyDir = dir
+	}
+}
+
+// WithIPSecStaticSA makes the forwarder request the ipsec mechanism with manually keyed ESP SAs instead of IKEv2.
+// Both modes are accepted on the server side regardless of the option
+func WithIPSecStaticSA(opts ...staticsa.Option) Option {
+	return func(o *forwarderOptions) {
+		o.ipsecStaticSA = true
+		o.ipsecStaticSAOpts = opts
+	}
+}
+
 // WithDialOptions sets dial options
 func WithDialOptions(opts ...grpc.DialOption) Option {
 	return func(o *forwarderOptions) {
@@ -25,6 +25,8 @@ package forwarder
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
 	"net"
 	"net/url"
 	"sync"
@@ -49,10 +51,12 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/sendfd"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanismtranslation"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/roundrobin"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	authmonitor "github.com/networkservicemesh/sdk/pkg/tools/monitorconnection/authorize"
 	"github.com/networkservicemesh/sdk/pkg/tools/token"
 
+	nullnetworkservice "github.com/networkservicemesh/sdk/pkg/networkservice/common/null"
 	registryclient "github.com/networkservicemesh/sdk/pkg/registry/chains/client"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/null"
 	registryrecvfd "github.com/networkservicemesh/sdk/pkg/registry/common/recvfd"
@@ -61,18 +65,25 @@ import (
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/connectioncontextkernel"
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/ethernetcontext"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/abf"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/afxdppinhole"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/connectioncontext/mtu"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/ipsec/staticsa"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/memif"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/srv6"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vhostuser"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vlan"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vxlan"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/wireguard"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/metrics"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/nsmonitor"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/pinhole"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/rxmode"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/rxplacement"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/tag"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/twicenat"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/up"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/l2bridgedomain"
@@ -114,33 +125,63 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, vppConn
 		registryclient.WithNSRetryClient(null.NewNetworkServiceRegistryClient()),
 	)
 
-	ikev2Key, err := ipsec.GenerateRSAKey()
+	if err := ipsec.SweepKeyDir(opts.ipsecKeyDir); err != nil {
+		log.FromContext(ctx).Errorf("error ipsec.SweepKeyDir: %v", err.Error())
+	}
+	ikev2Key, err := rsa.GenerateKey(rand.Reader, 2048)
 	if err != nil {
-		log.FromContext(ctx).Fatalf("error ipsec.GenerateRSAKey: %v", err.Error())
+		log.FromContext(ctx).Fatalf("error generating IKEv2 RSA key: %v", err.Error())
+	}
+	ipsecOpts := append([]ipsec.Option{
+		ipsec.WithIKEv2RSAPrivateKey(ikev2Key),
+		ipsec.WithKeyDir(opts.ipsecKeyDir),
+	}, opts.ipsecOpts...)
+	var ipsecClient networkservice.NetworkServiceClient
+	if opts.ipsecStaticSA {
+		ipsecClient = staticsa.NewClient(vppConn, tunnelIP, opts.ipsecStaticSAOpts...)
+	} else {
+		ipsecClient = ipsec.NewClient(vppConn, tunnelIP, ipsecOpts...)
+	}
+	srv6Server, srv6Client := nullnetworkservice.NewServer(), nullnetworkservice.NewClient()
+	if opts.srv6Opts != nil {
+		srv6Server = srv6.NewServer(vppConn, tunnelIP, opts.srv6Opts...)
+		srv6Client = srv6.NewClient(vppConn, tunnelIP, opts.srv6Opts...)
 	}
 	rv := &xconnectNSServer{}
 	pinholeMutex := new(sync.Mutex)
+	rxPlacer := rxplacement.NewPlacer(ctx, vppConn, opts.rxPlacementOpts...)
 	additionalFunctionality := []networkservice.NetworkServiceServer{
 		recvfd.NewServer(),
 		sendfd.NewServer(),
 		discover.NewServer(nsClient, nseClient),
 		roundrobin.NewServer(),
 		metrics.NewServer(ctx, vppConn, opts.metricsOpts...),
+		rxmode.NewServer(ctx, vppConn, opts.rxModeOpts...),
+		rxplacement.NewServer(ctx, vppConn, rxplacement.WithSharedPlacer(rxPlacer)),
 		up.NewServer(ctx, vppConn),
 		xconnect.NewServer(vppConn),
-		l2bridgedomain.NewServer(vppConn),
+		abf.NewServer(vppConn, opts.abfOpts...),
+		twicenat.NewServer(vppConn, opts.twiceNATOpts...),
+		l2bridgedomain.NewServer(vppConn, opts.l2BridgeDomainOpts...),
 		connectioncontextkernel.NewServer(),
 		ethernetcontext.NewVFServer(),
 		tag.NewServer(ctx, vppConn),
 		mtu.NewServer(vppConn),
 		mechanisms.NewServer(map[string]networkservice.NetworkServiceServer{
 			memif.MECHANISM: memif.NewServer(ctx, vppConn,
-				memif.WithDirectMemif(),
-				memif.WithChangeNetNS()),
-			kernel.MECHANISM:    kernel.NewServer(vppConn),
+				append([]memif.Option{
+					memif.WithDirectMemif(),
+					memif.WithChangeNetNS(),
+				}, opts.memifOpts...)...),
+			vhostuser.MECHANISM: vhostuser.NewServer(vppConn, opts.vhostUserOpts...),
+			kernel.MECHANISM:    kernel.NewServer(vppConn, opts.kernelOpts...),
 			vxlan.MECHANISM:     vxlan.NewServer(vppConn, tunnelIP, opts.vxlanOpts...),
 			wireguard.MECHANISM: wireguard.NewServer(vppConn, tunnelIP),
-			ipsecapi.MECHANISM:  ipsec.NewServer(vppConn, tunnelIP, ipsec.WithIKEv2PrivateKey(ikev2Key)),
+			ipsecapi.MECHANISM: chain.NewNetworkServiceServer(
+				staticsa.NewServer(vppConn, tunnelIP),
+				ipsec.NewServer(vppConn, tunnelIP, ipsecOpts...),
+			),
+			srv6.MECHANISM: srv6Server,
 		}),
 		afxdppinhole.NewServer(),
 		pinhole.NewServer(vppConn, pinhole.WithSharedMutex(pinholeMutex)),
@@ -156,18 +197,24 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, vppConn
 						mechanismtranslation.NewClient(),
 						connectioncontextkernel.NewClient(),
 						metrics.NewClient(ctx, vppConn, opts.metricsOpts...),
+						rxmode.NewClient(ctx, vppConn, opts.rxModeOpts...),
+						rxplacement.NewClient(ctx, vppConn, rxplacement.WithSharedPlacer(rxPlacer)),
 						up.NewClient(ctx, vppConn),
 						mtu.NewClient(vppConn),
 						tag.NewClient(ctx, vppConn),
 						// mechanisms
 						memif.NewClient(ctx, vppConn,
-							memif.WithChangeNetNS(),
+							append([]memif.Option{
+								memif.WithChangeNetNS(),
+							}, opts.memifOpts...)...,
 						),
-						kernel.NewClient(vppConn),
+						vhostuser.NewClient(vppConn, opts.vhostUserOpts...),
+						kernel.NewClient(vppConn, opts.kernelOpts...),
 						vxlan.NewClient(vppConn, tunnelIP, opts.vxlanOpts...),
 						wireguard.NewClient(vppConn, tunnelIP),
-						ipsec.NewClient(vppConn, tunnelIP, ipsec.WithIKEv2PrivateKey(ikev2Key)),
-						vlan.NewClient(vppConn, opts.domain2Device),
+						ipsecClient,
+						srv6Client,
+						vlan.NewClient(vppConn, opts.domain2Device, opts.vlanOpts...),
 						filtermechanisms.NewClient(),
 						mechanismpriority.NewClient(opts.mechanismPrioriyList...),
 						afxdppinhole.NewClient(),
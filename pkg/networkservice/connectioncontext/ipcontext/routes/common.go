@@ -48,9 +48,35 @@ func addDel(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 		routes = conn.GetContext().GetIpContext().GetSrcIPRoutes()
 		routes = append(routes, conn.GetContext().GetIpContext().GetDstRoutesWithExplicitNextHop()...)
 	}
+	vppRoutes, err := toRoutes(ctx, routes, swIfIndex, isClient)
+	if err != nil {
+		return err
+	}
 
-	for _, route := range routes {
-		if err := routeAddDel(ctx, vppConn, swIfIndex, isClient, isAdd, route); err != nil {
+	if !isAdd {
+		if applied, ok := loadAndDelete(ctx, isClient); ok {
+			vppRoutes = applied
+		}
+		for _, vppRoute := range vppRoutes {
+			if err := routeAddDel(ctx, vppConn, swIfIndex, false, vppRoute); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Remove the paths the connection does not have anymore, keeping the paths of other connections to the same prefix
+	applied, _ := load(ctx, isClient)
+	for _, vppRoute := range applied {
+		if removed := removedPaths(vppRoute, vppRoutes); removed != nil {
+			if err := routeAddDel(ctx, vppConn, swIfIndex, false, removed); err != nil {
+				return err
+			}
+		}
+	}
+	store(ctx, isClient, vppRoutes)
+	for _, vppRoute := range vppRoutes {
+		if err := routeAddDel(ctx, vppConn, swIfIndex, true, vppRoute); err != nil {
 			return err
 		}
 	}
@@ -58,54 +84,107 @@ func addDel(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 	return nil
 }
 
-func routeAddDel(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, isClient, isAdd bool, route *networkservice.Route) error {
-	if route.GetPrefixIPNet() == nil {
-		return errors.New("vppRoute prefix must not be nil")
-	}
-	isIPV6 := route.GetPrefixIPNet().IP.To4() == nil
-	tableID, _ := vrf.Load(ctx, isClient, isIPV6)
-	vppRoute := toRoute(route, swIfIndex, tableID)
+func routeAddDel(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, isAdd bool, vppRoute *ip.IPRoute) error {
 	now := time.Now()
 	if _, err := ip.NewServiceClient(vppConn).IPRouteAddDel(ctx, &ip.IPRouteAddDel{
 		IsAdd:       isAdd,
-		IsMultipath: false,
-		Route:       vppRoute,
+		IsMultipath: true,
+		Route:       *vppRoute,
 	}); err != nil {
 		return errors.Wrap(err, "vppapi IPRouteAddDel returned error")
 	}
 	log.FromContext(ctx).
 		WithField("swIfIndex", swIfIndex).
 		WithField("prefix", vppRoute.Prefix).
-		WithField("isIpV6", isIPV6).
-		WithField("tableID", tableID).
+		WithField("nPaths", vppRoute.NPaths).
+		WithField("tableID", vppRoute.TableID).
 		WithField("isAdd", isAdd).
 		WithField("duration", time.Since(now)).
 		WithField("vppapi", "IPRouteAddDel").Debug("completed")
 	return nil
 }
 
-func toRoute(route *networkservice.Route, via interface_types.InterfaceIndex, tableID uint32) ip.IPRoute {
+// toRoutes - merges the routes to the same prefix into multipath routes. networkservice.Route has no weight, so a
+// next hop listed several times gets the weight of the number of its entries
+func toRoutes(ctx context.Context, routes []*networkservice.Route, via interface_types.InterfaceIndex, isClient bool) ([]*ip.IPRoute, error) {
+	var rv []*ip.IPRoute
+	byPrefix := make(map[string]*ip.IPRoute)
+	for _, route := range routes {
+		prefix := route.GetPrefixIPNet()
+		if prefix == nil {
+			return nil, errors.New("vppRoute prefix must not be nil")
+		}
+		path := toPath(route, via)
+		vppRoute, ok := byPrefix[prefix.String()]
+		if !ok {
+			tableID, _ := vrf.Load(ctx, isClient, prefix.IP.To4() == nil)
+			vppRoute = &ip.IPRoute{
+				TableID: tableID,
+				Prefix:  types.ToVppPrefix(prefix),
+			}
+			byPrefix[prefix.String()] = vppRoute
+			rv = append(rv, vppRoute)
+		}
+		if i := pathIndex(vppRoute.Paths, path, false); i >= 0 {
+			vppRoute.Paths[i].Weight++
+			continue
+		}
+		vppRoute.Paths = append(vppRoute.Paths, path)
+		vppRoute.NPaths++
+	}
+	return rv, nil
+}
+
+func toPath(route *networkservice.Route, via interface_types.InterfaceIndex) fib_types.FibPath {
 	prefix := route.GetPrefixIPNet()
-	rv := ip.IPRoute{
-		TableID:    tableID,
-		StatsIndex: 0,
-		Prefix:     types.ToVppPrefix(prefix),
-		NPaths:     1,
-		Paths: []fib_types.FibPath{
-			{
-				SwIfIndex: uint32(via),
-				TableID:   0,
-				RpfID:     0,
-				Weight:    1,
-				Type:      fib_types.FIB_API_PATH_TYPE_NORMAL,
-				Flags:     fib_types.FIB_API_PATH_FLAG_NONE,
-				Proto:     types.IsV6toFibProto(prefix.IP.To4() == nil),
-			},
-		},
+	path := fib_types.FibPath{
+		SwIfIndex: uint32(via),
+		TableID:   0,
+		RpfID:     0,
+		Weight:    1,
+		Type:      fib_types.FIB_API_PATH_TYPE_NORMAL,
+		Flags:     fib_types.FIB_API_PATH_FLAG_NONE,
+		Proto:     types.IsV6toFibProto(prefix.IP.To4() == nil),
+	}
+	if nh := route.GetNextHopIP(); nh != nil {
+		path.Nh.Address = types.ToVppAddress(nh).Un
+	}
+	return path
+}
+
+// pathIndex - returns the index of the path going to the same next hop, -1 if there is none
+func pathIndex(paths []fib_types.FibPath, path fib_types.FibPath, withWeight bool) int {
+	for i := range paths {
+		if paths[i].SwIfIndex == path.SwIfIndex && paths[i].Nh.Address == path.Nh.Address &&
+			(!withWeight || paths[i].Weight == path.Weight) {
+			return i
+		}
 	}
-	nh := route.GetNextHopIP()
-	if nh != nil {
-		rv.Paths[0].Nh.Address = types.ToVppAddress(nh).Un
+	return -1
+}
+
+// removedPaths - returns the route with the paths of the applied route missing (or reweighted) in the routes, nil if
+// there are none
+func removedPaths(applied *ip.IPRoute, routes []*ip.IPRoute) *ip.IPRoute {
+	var paths []fib_types.FibPath
+	for _, route := range routes {
+		if route.TableID == applied.TableID && route.Prefix == applied.Prefix {
+			paths = route.Paths
+			break
+		}
+	}
+	removed := &ip.IPRoute{
+		TableID: applied.TableID,
+		Prefix:  applied.Prefix,
+	}
+	for _, path := range applied.Paths {
+		if pathIndex(paths, path, true) < 0 {
+			removed.Paths = append(removed.Paths, path)
+			removed.NPaths++
+		}
+	}
+	if removed.NPaths == 0 {
+		return nil
 	}
-	return rv
+	return removed
 }
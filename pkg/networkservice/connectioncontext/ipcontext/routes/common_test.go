@@ -0,0 +1,153 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/govpp/binapi/fib_types"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/govpp/binapi/ip"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/checks/checkcontext"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+const via = interface_types.InterfaceIndex(5)
+
+func vppRoute(prefix string, paths ...fib_types.FibPath) *ip.IPRoute {
+	_, ipNet, _ := net.ParseCIDR(prefix)
+	return &ip.IPRoute{
+		Prefix: types.ToVppPrefix(ipNet),
+		NPaths: uint8(len(paths)),
+		Paths:  paths,
+	}
+}
+
+func vppPath(nextHop string, weight uint8) fib_types.FibPath {
+	nh := net.ParseIP(nextHop)
+	path := fib_types.FibPath{
+		SwIfIndex: uint32(via),
+		Weight:    weight,
+		Type:      fib_types.FIB_API_PATH_TYPE_NORMAL,
+		Flags:     fib_types.FIB_API_PATH_FLAG_NONE,
+		Proto:     types.IsV6toFibProto(nh.To4() == nil),
+	}
+	path.Nh.Address = types.ToVppAddress(nh).Un
+	return path
+}
+
+func TestToRoutes(t *testing.T) {
+	tests := []struct {
+		name     string
+		routes   []*networkservice.Route
+		expected []*ip.IPRoute
+	}{
+		{
+			name: "MergesByPrefix",
+			routes: []*networkservice.Route{
+				{Prefix: "10.0.0.0/24", NextHop: "172.16.0.1"},
+				{Prefix: "10.0.1.0/24", NextHop: "172.16.0.1"},
+				{Prefix: "10.0.0.0/24", NextHop: "172.16.0.2"},
+			},
+			expected: []*ip.IPRoute{
+				vppRoute("10.0.0.0/24", vppPath("172.16.0.1", 1), vppPath("172.16.0.2", 1)),
+				vppRoute("10.0.1.0/24", vppPath("172.16.0.1", 1)),
+			},
+		},
+		{
+			name: "WeightsFromDuplicates",
+			routes: []*networkservice.Route{
+				{Prefix: "10.0.0.0/24", NextHop: "172.16.0.1"},
+				{Prefix: "10.0.0.0/24", NextHop: "172.16.0.2"},
+				{Prefix: "10.0.0.0/24", NextHop: "172.16.0.1"},
+				{Prefix: "10.0.0.0/24", NextHop: "172.16.0.1"},
+			},
+			expected: []*ip.IPRoute{
+				vppRoute("10.0.0.0/24", vppPath("172.16.0.1", 3), vppPath("172.16.0.2", 1)),
+			},
+		},
+		{
+			name: "IPv6",
+			routes: []*networkservice.Route{
+				{Prefix: "fd00::/64", NextHop: "fe80::1"},
+				{Prefix: "fd00::/64", NextHop: "fe80::2"},
+			},
+			expected: []*ip.IPRoute{
+				vppRoute("fd00::/64", vppPath("fe80::1", 1), vppPath("fe80::2", 1)),
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			server := chain.NewNetworkServiceServer(
+				metadata.NewServer(),
+				checkcontext.NewServer(t, func(t *testing.T, ctx context.Context) {
+					actual, err := toRoutes(ctx, tt.routes, via, false)
+					require.NoError(t, err)
+					require.Equal(t, tt.expected, actual)
+				}),
+			)
+			_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+				Connection: &networkservice.Connection{Id: t.Name()},
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRemovedPaths(t *testing.T) {
+	applied := vppRoute("10.0.0.0/24", vppPath("172.16.0.1", 2), vppPath("172.16.0.2", 1))
+	tests := []struct {
+		name     string
+		routes   []*ip.IPRoute
+		expected *ip.IPRoute
+	}{
+		{
+			name:   "Unchanged",
+			routes: []*ip.IPRoute{vppRoute("10.0.0.0/24", vppPath("172.16.0.1", 2), vppPath("172.16.0.2", 1))},
+		},
+		{
+			name:     "PartlyRemoved",
+			routes:   []*ip.IPRoute{vppRoute("10.0.0.0/24", vppPath("172.16.0.1", 2))},
+			expected: vppRoute("10.0.0.0/24", vppPath("172.16.0.2", 1)),
+		},
+		{
+			name:     "Reweighted",
+			routes:   []*ip.IPRoute{vppRoute("10.0.0.0/24", vppPath("172.16.0.1", 1), vppPath("172.16.0.2", 1))},
+			expected: vppRoute("10.0.0.0/24", vppPath("172.16.0.1", 2)),
+		},
+		{
+			name:     "CompletelyRemoved",
+			routes:   []*ip.IPRoute{vppRoute("10.0.1.0/24", vppPath("172.16.0.1", 2))},
+			expected: vppRoute("10.0.0.0/24", vppPath("172.16.0.1", 2), vppPath("172.16.0.2", 1)),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, removedPaths(applied, tt.routes))
+		})
+	}
+}
@@ -18,9 +18,9 @@ package ipsec
 
 import (
 	"context"
-	"crypto/rsa"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
@@ -44,38 +44,36 @@ import (
 type ipsecClient struct {
 	vppConn  api.Connection
 	tunnelIP net.IP
+	identity *identity
 
-	privateKeyFileName string
-	privateKey         *rsa.PrivateKey
-	onceInit           sync.Once
+	livenessPeriod     time.Duration
+	livenessMaxRetries uint32
+	livenessOnce       sync.Once
 }
 
 // NewClient - returns a new client for the IPSec remote mechanism
 func NewClient(vppConn api.Connection, tunnelIP net.IP, options ...Option) networkservice.NetworkServiceClient {
-	opts := &ipsecOptions{}
+	opts := &ipsecOptions{
+		livenessPeriod:     defaultLivenessPeriod,
+		livenessMaxRetries: defaultLivenessMaxRetries,
+	}
 	for _, opt := range options {
 		opt(opts)
 	}
 
-	if opts.privateKeyFileName == "" {
-		var err error
-		opts.privateKeyFileName, err = GenerateRSAKey()
-		if err != nil {
-			log.FromContext(context.Background()).Fatalf("ipsecClient GenerateRSAKey error: %v", err)
-		}
-	}
-
-	privateKey, err := privateKeyFromFile(opts.privateKeyFileName)
+	id, err := newIdentity(vppConn, opts)
 	if err != nil {
-		log.FromContext(context.Background()).Fatalf("ipsecClient privateKeyFromFile error: %v", err)
+		log.FromContext(context.Background()).Fatalf("ipsecClient identity error: %v", err)
 	}
 
 	return chain.NewNetworkServiceClient(
 		&ipsecClient{
-			vppConn:            vppConn,
-			tunnelIP:           tunnelIP,
-			privateKeyFileName: opts.privateKeyFileName,
-			privateKey:         privateKey,
+			vppConn:  vppConn,
+			tunnelIP: tunnelIP,
+			identity: id,
+
+			livenessPeriod:     opts.livenessPeriod,
+			livenessMaxRetries: opts.livenessMaxRetries,
 		},
 		mtu.NewClient(vppConn, tunnelIP),
 	)
@@ -86,20 +84,21 @@ func (i *ipsecClient) Request(ctx context.Context, request *networkservice.Netwo
 		return next.Client(ctx).Request(ctx, request, opts...)
 	}
 	var err error
-	i.onceInit.Do(func() {
-		err = setIKEv2LocalKey(ctx, i.vppConn, i.privateKeyFileName)
+	i.livenessOnce.Do(func() {
+		err = setLiveness(ctx, i.vppConn, i.livenessPeriod, i.livenessMaxRetries)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	certificate, err := createCertBase64(i.privateKey, metadata.IsClient(i))
+	certificate, err := i.identity.certificate(ctx, metadata.IsClient(i))
 	if err != nil {
 		return nil, err
 	}
 	// If we already have a key we can reuse it
-	// else create a new one and store it after successful interface creation
-	if mechanism := ipsecMech.ToMechanism(request.GetConnection().GetMechanism()); mechanism != nil {
+	// else create a new one and store it after successful interface creation.
+	// X.509-SVIDs are always sent as is, so that the peer gets the rotated certificate
+	if mechanism := toMechanism(request.GetConnection().GetMechanism()); mechanism != nil && !i.identity.isX509SVID() {
 		// If there is a key in mechanism then we can use it
 		certificate = mechanism.SrcPublicKey()
 	}
@@ -126,7 +125,7 @@ func (i *ipsecClient) Request(ctx context.Context, request *networkservice.Netwo
 		return nil, err
 	}
 
-	if err = create(ctx, conn, i.vppConn, metadata.IsClient(i)); err != nil {
+	if err = create(ctx, conn, i.vppConn, i.identity, metadata.IsClient(i)); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -141,8 +140,8 @@ func (i *ipsecClient) Request(ctx context.Context, request *networkservice.Netwo
 }
 
 func (i *ipsecClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
-	if mechanism := ipsecMech.ToMechanism(conn.GetMechanism()); mechanism != nil {
-		delInterface(ctx, conn, i.vppConn, metadata.IsClient(i))
+	if mechanism := toMechanism(conn.GetMechanism()); mechanism != nil {
+		delInterface(ctx, conn, i.vppConn, i.identity.keys, metadata.IsClient(i))
 	}
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
@@ -39,11 +39,11 @@ import (
 	interfaces "github.com/networkservicemesh/govpp/binapi/interface"
 	"github.com/networkservicemesh/govpp/binapi/interface_types"
 	"github.com/networkservicemesh/govpp/binapi/ip"
-	"github.com/networkservicemesh/govpp/binapi/ip_types"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/pkg/errors"
 	"go.fd.io/govpp/api"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/ipsec/staticsa"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
 
@@ -53,14 +53,25 @@ import (
 	ipsecapi "github.com/networkservicemesh/govpp/binapi/ipsec"
 )
 
+// toMechanism - returns the ipsec mechanism or nil if it isn't the ipsec mechanism based on IKEv2
+func toMechanism(m *networkservice.Mechanism) *ipsec.Mechanism {
+	if staticsa.IsStatic(m) {
+		return nil
+	}
+	return ipsec.ToMechanism(m)
+}
+
 // create - creates IPSEC with IKEv2
-func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
-	if mechanism := ipsec.ToMechanism(conn.GetMechanism()); mechanism != nil {
+func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, id *identity, isClient bool) error {
+	if mechanism := toMechanism(conn.GetMechanism()); mechanism != nil {
+		profileName := fmt.Sprintf("%s-%s", isClientPrefix(isClient), conn.Id)
 		_, ok := ifindex.Load(ctx, isClient)
 		if ok {
-			return nil
+			if err := updateAuth(ctx, conn, vppConn, id, mechanism, profileName, isClient); err != nil {
+				return err
+			}
+			return updateTrafficSelector(ctx, conn, vppConn, profileName, isClient)
 		}
-		profileName := fmt.Sprintf("%s-%s", isClientPrefix(isClient), conn.Id)
 
 		// *** CREATE IP TUNNEL *** //
 		swIfIndex, err := createIPSecTunnel(ctx, vppConn)
@@ -80,14 +91,8 @@ func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 			return err
 		}
 
-		// *** SET KEYS *** //
-		err = setKeys(ctx, vppConn, profileName, mechanism, isClient)
-		if err != nil {
-			return err
-		}
-
-		// *** SET FQDN *** //
-		err = setFQDN(ctx, vppConn, mechanism, profileName, isClient)
+		// *** SET KEYS AND IDS *** //
+		err = setAuth(ctx, conn, vppConn, id, mechanism, profileName, isClient)
 		if err != nil {
 			return err
 		}
@@ -117,6 +122,51 @@ func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 	return nil
 }
 
+// updateAuth - updates the peer certificate and IDs of the existing profile if the peer certificate has been changed (e.g. rotated)
+func updateAuth(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, id *identity, mechanism *ipsec.Mechanism, profileName string, isClient bool) error {
+	if !id.isX509SVID() {
+		return nil
+	}
+	if oldPeerCert, ok := loadPeerCert(ctx, isClient); ok && oldPeerCert == peerCertificate(mechanism, isClient) {
+		return nil
+	}
+	return setAuth(ctx, conn, vppConn, id, mechanism, profileName, isClient)
+}
+
+func setAuth(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, id *identity, mechanism *ipsec.Mechanism, profileName string, isClient bool) error {
+	idType, localID, remoteID, err := id.ikeIDs(conn, mechanism, isClient)
+	if err != nil {
+		return err
+	}
+
+	// *** SET KEYS *** //
+	err = setKeys(ctx, vppConn, id.keys, profileName, mechanism, isClient)
+	if err != nil {
+		return err
+	}
+
+	// *** SET IDS *** //
+	err = setID(ctx, vppConn, profileName, idType, localID, true)
+	if err != nil {
+		return err
+	}
+	err = setID(ctx, vppConn, profileName, idType, remoteID, false)
+	if err != nil {
+		return err
+	}
+
+	storePeerCert(ctx, isClient, peerCertificate(mechanism, isClient))
+	storeIkeIDs(ctx, isClient, &ikeIDs{local: localID, remote: remoteID})
+	return nil
+}
+
+func peerCertificate(mechanism *ipsec.Mechanism, isClient bool) string {
+	if isClient {
+		return mechanism.DstPublicKey()
+	}
+	return mechanism.SrcPublicKey()
+}
+
 func initiate(ctx context.Context, vppConn api.Connection, mechanism *ipsec.Mechanism, profileName string) error {
 	hostSwIfIndex, err := getSwIfIndexByIP(ctx, vppConn, mechanism.SrcIP())
 	if err != nil {
@@ -257,19 +307,20 @@ func setUDPEncap(ctx context.Context, vppConn api.Connection, profileName string
 	return nil
 }
 
-func setKeys(ctx context.Context, vppConn api.Connection, profileName string, mechanism *ipsec.Mechanism, isClient bool) error {
-	publicKeyBase64 := mechanism.SrcPublicKey()
-	if isClient {
-		publicKeyBase64 = mechanism.DstPublicKey()
-	}
-	publicKeyFileName, err := dumpCertBase64ToFile(publicKeyBase64, profileName)
+func setKeys(ctx context.Context, vppConn api.Connection, keys *keyStore, profileName string, mechanism *ipsec.Mechanism, isClient bool) error {
+	certBlock, err := certPEMBlock(peerCertificate(mechanism, isClient))
 	if err != nil {
 		return err
 	}
-	log.FromContext(ctx).WithField("operation", "dumpCertBase64ToFile").Debug("completed")
 
+	return keys.load(ctx, profileName, certBlock, func(publicKeyFileName string) error {
+		return setProfileAuth(ctx, vppConn, profileName, publicKeyFileName)
+	})
+}
+
+func setProfileAuth(ctx context.Context, vppConn api.Connection, profileName, publicKeyFileName string) error {
 	now := time.Now()
-	_, err = ikev2.NewServiceClient(vppConn).Ikev2ProfileSetAuth(ctx, &ikev2.Ikev2ProfileSetAuth{
+	_, err := ikev2.NewServiceClient(vppConn).Ikev2ProfileSetAuth(ctx, &ikev2.Ikev2ProfileSetAuth{
 		Name:       profileName,
 		AuthMethod: 1, // rsa-sig
 		DataLen:    uint32(len(publicKeyFileName)),
@@ -300,102 +351,117 @@ func setIKEv2LocalKey(ctx context.Context, vppConn api.Connection, privateKeyFil
 	return nil
 }
 
-func setFQDN(ctx context.Context, vppConn api.Connection, mechanism *ipsec.Mechanism, profileName string, isClient bool) error {
+func setID(ctx context.Context, vppConn api.Connection, profileName string, idType uint8, data string, isLocal bool) error {
 	now := time.Now()
-
-	// We need unique values per client/server. Using public keys
-	fqdnLocal := mechanism.SrcPublicKey()[:64]
-	fqdnRemote := mechanism.DstPublicKey()[:64]
-	if !isClient {
-		fqdnLocal, fqdnRemote = fqdnRemote, fqdnLocal
-	}
 	_, err := ikev2.NewServiceClient(vppConn).Ikev2ProfileSetID(ctx, &ikev2.Ikev2ProfileSetID{
 		Name:    profileName,
-		IsLocal: true,
-		IDType:  2, // FQDN
-		DataLen: uint32(len(fqdnLocal)),
-		Data:    []byte(fqdnLocal),
+		IsLocal: isLocal,
+		IDType:  idType,
+		DataLen: uint32(len(data)),
+		Data:    []byte(data),
 	})
 	if err != nil {
 		return errors.Wrap(err, "vppapi Ikev2ProfileSetID returned error")
 	}
 	log.FromContext(ctx).
 		WithField("Name", profileName).
-		WithField("IsLocal", "true").
+		WithField("IsLocal", isLocal).
+		WithField("IDType", idType).
 		WithField("duration", time.Since(now)).
 		WithField("vppapi", "Ikev2ProfileSetID").Debug("completed")
+	return nil
+}
 
-	now = time.Now()
-	_, err = ikev2.NewServiceClient(vppConn).Ikev2ProfileSetID(ctx, &ikev2.Ikev2ProfileSetID{
-		Name:    profileName,
-		IsLocal: false,
-		IDType:  2, // FQDN
-		DataLen: uint32(len(fqdnRemote)),
-		Data:    []byte(fqdnRemote),
-	})
+// updateTrafficSelector - updates the traffic selectors of the existing profile if the IP context has been changed.
+// The initiator renegotiates the IKE SA to bring the new selectors to the child SA.
+func updateTrafficSelector(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, profileName string, isClient bool) error {
+	selectors, err := trafficSelectors(conn, isClient)
 	if err != nil {
-		return errors.Wrap(err, "vppapi Ikev2ProfileSetID returned error")
+		return err
 	}
-	log.FromContext(ctx).
-		WithField("Name", profileName).
-		WithField("IsLocal", "false").
-		WithField("duration", time.Since(now)).
-		WithField("vppapi", "Ikev2ProfileSetID").Debug("completed")
-	return nil
+	if oldKey, ok := loadTrafficSelectorsKey(ctx, isClient); ok && oldKey == trafficSelectorsKey(selectors) {
+		return nil
+	}
+	if err := setTrafficSelector(ctx, vppConn, profileName, conn, isClient); err != nil {
+		return err
+	}
+	if !isClient {
+		return nil
+	}
+
+	// The IDs are taken from the profile: the certificates without X.509-SVIDs are regenerated on every refresh
+	ids, ok := loadIkeIDs(ctx, isClient)
+	if !ok {
+		return errors.Errorf("no IKEv2 IDs are set for the profile %s", profileName)
+	}
+	storeRenegotiation(ctx, isClient)
+	if err := delIkeSa(ctx, vppConn, ids.local, ids.remote); err != nil {
+		DeleteRenegotiation(ctx, isClient)
+		return err
+	}
+	return saInit(ctx, vppConn, profileName)
 }
 
 func setTrafficSelector(ctx context.Context, vppConn api.Connection, profileName string, conn *networkservice.Connection, isClient bool) error {
-	now := time.Now()
-	for _, addr := range conn.GetContext().GetIpContext().GetSrcIpAddrs() {
-		a, err := ip_types.ParseAddressWithPrefix(addr)
-		if err != nil {
-			return errors.Wrapf(err, "failed to parse address with prefix %s", addr)
-		}
+	selectors, err := trafficSelectors(conn, isClient)
+	if err != nil {
+		return err
+	}
+	for _, ts := range sortTrafficSelectors(selectors) {
+		now := time.Now()
 		_, err = ikev2.NewServiceClient(vppConn).Ikev2ProfileSetTs(ctx, &ikev2.Ikev2ProfileSetTs{
 			Name: profileName,
-			Ts: ikev2_types.Ikev2Ts{
-				IsLocal:   isClient,
-				StartPort: 0,
-				EndPort:   65535,
-				StartAddr: a.Address,
-				EndAddr:   a.Address,
-			},
+			Ts:   ts.toVpp(),
 		})
 		if err != nil {
 			return errors.Wrap(err, "vppapi Ikev2ProfileSetTs returned error")
 		}
 		log.FromContext(ctx).
 			WithField("Name", profileName).
-			WithField("IsLocal", isClient).
-			WithField("Address", addr).
+			WithField("IsLocal", ts.isLocal).
+			WithField("StartAddr", ts.start).
+			WithField("EndAddr", ts.end).
 			WithField("duration", time.Since(now)).
 			WithField("vppapi", "Ikev2ProfileSetTs").Debug("completed")
 	}
+	storeTrafficSelectorsKey(ctx, isClient, trafficSelectorsKey(selectors))
+	return nil
+}
 
-	for _, addr := range conn.GetContext().GetIpContext().GetDstIpAddrs() {
-		a, err := ip_types.ParseAddressWithPrefix(addr)
+// delIkeSa - initiates deletion of the IKE SA established between the local and remote IDs
+func delIkeSa(ctx context.Context, vppConn api.Connection, localID, remoteID string) error {
+	client, err := ikev2.NewServiceClient(vppConn).Ikev2SaDump(ctx, &ikev2.Ikev2SaDump{})
+	if err != nil {
+		return errors.Wrap(err, "vppapi Ikev2SaDump returned error")
+	}
+	defer func() { _ = client.Close() }()
+
+	var ispis []uint64
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
-			return errors.Wrapf(err, "failed to parse address with prefix %s", addr)
+			return errors.Wrap(err, "vppapi Ikev2SaDump returned error")
 		}
-		_, err = ikev2.NewServiceClient(vppConn).Ikev2ProfileSetTs(ctx, &ikev2.Ikev2ProfileSetTs{
-			Name: profileName,
-			Ts: ikev2_types.Ikev2Ts{
-				IsLocal:   !isClient,
-				StartPort: 0,
-				EndPort:   65535,
-				StartAddr: a.Address,
-				EndAddr:   a.Address,
-			},
+		if details.Sa.IID.Data == localID && details.Sa.RID.Data == remoteID {
+			ispis = append(ispis, details.Sa.Ispi)
+		}
+	}
+
+	for _, ispi := range ispis {
+		now := time.Now()
+		_, err := ikev2.NewServiceClient(vppConn).Ikev2InitiateDelIkeSa(ctx, &ikev2.Ikev2InitiateDelIkeSa{
+			Ispi: ispi,
 		})
 		if err != nil {
-			return errors.Wrap(err, "vppapi Ikev2ProfileSetTs returned error")
+			return errors.Wrap(err, "vppapi Ikev2InitiateDelIkeSa returned error")
 		}
 		log.FromContext(ctx).
-			WithField("Name", profileName).
-			WithField("IsLocal", !isClient).
-			WithField("Address", addr).
+			WithField("Ispi", ispi).
 			WithField("duration", time.Since(now)).
-			WithField("vppapi", "Ikev2ProfileSetTs").Debug("completed")
+			WithField("vppapi", "Ikev2InitiateDelIkeSa").Debug("completed")
 	}
 	return nil
 }
@@ -480,7 +546,7 @@ func setSaLifetime(ctx context.Context, vppConn api.Connection, profileName stri
 	now := time.Now()
 	_, err := ikev2.NewServiceClient(vppConn).Ikev2SetSaLifetime(ctx, &ikev2.Ikev2SetSaLifetime{
 		Name:            profileName,
-		Lifetime:        3600,
+		Lifetime:        uint64(SaLifetime.Seconds()),
 		LifetimeJitter:  10,
 		Handover:        5,
 		LifetimeMaxdata: 0,
@@ -495,6 +561,23 @@ func setSaLifetime(ctx context.Context, vppConn api.Connection, profileName stri
 	return nil
 }
 
+func setLiveness(ctx context.Context, vppConn api.Connection, period time.Duration, maxRetries uint32) error {
+	now := time.Now()
+	_, err := ikev2.NewServiceClient(vppConn).Ikev2ProfileSetLiveness(ctx, &ikev2.Ikev2ProfileSetLiveness{
+		Period:     uint32(period.Seconds()),
+		MaxRetries: maxRetries,
+	})
+	if err != nil {
+		return errors.Wrap(err, "vppapi Ikev2ProfileSetLiveness returned error")
+	}
+	log.FromContext(ctx).
+		WithField("Period", period).
+		WithField("MaxRetries", maxRetries).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "Ikev2ProfileSetLiveness").Debug("completed")
+	return nil
+}
+
 func saInit(ctx context.Context, vppConn api.Connection, profileName string) error {
 	now := time.Now()
 	_, err := ikev2.NewServiceClient(vppConn).Ikev2InitiateSaInit(ctx, &ikev2.Ikev2InitiateSaInit{
@@ -510,11 +593,18 @@ func saInit(ctx context.Context, vppConn api.Connection, profileName string) err
 	return nil
 }
 
-func delInterface(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) {
-	if mechanism := ipsec.ToMechanism(conn.GetMechanism()); mechanism != nil {
+func delInterface(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, keys *keyStore, isClient bool) {
+	if mechanism := toMechanism(conn.GetMechanism()); mechanism != nil {
 		profileName := fmt.Sprintf("%s-%s", isClientPrefix(isClient), conn.Id)
 		_ = addDelProfile(ctx, vppConn, profileName, false)
 		_ = delIPSecTunnel(ctx, vppConn, isClient)
+		deletePeerCert(ctx, isClient)
+		deleteTrafficSelectorsKey(ctx, isClient)
+		deleteIkeIDs(ctx, isClient)
+		DeleteRenegotiation(ctx, isClient)
+		if err := keys.remove(profileName); err != nil {
+			log.FromContext(ctx).Warnf("failed to remove key material of %s: %v", profileName, err)
+		}
 	}
 }
 
@@ -527,6 +617,13 @@ func generateRSAKey() (*rsa.PrivateKey, error) {
 	return key, nil
 }
 
+func privateKeyPEMBlock(privatekey *rsa.PrivateKey) *pem.Block {
+	return &pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privatekey),
+	}
+}
+
 func dumpPrivateKeyToFile(privatekey *rsa.PrivateKey, folderName string) (string, error) {
 	dir := path.Join(os.TempDir(), "networkservicemesh", folderName)
 	err := os.MkdirAll(dir, 0o700)
@@ -534,18 +631,14 @@ func dumpPrivateKeyToFile(privatekey *rsa.PrivateKey, folderName string) (string
 		return "", errors.Wrapf(err, "failed to create directory %s", dir)
 	}
 
-	privateKeyBytes := x509.MarshalPKCS1PrivateKey(privatekey)
-	privateKeyBlock := &pem.Block{
-		Type:  "RSA PRIVATE KEY",
-		Bytes: privateKeyBytes,
-	}
-
-	keyName := "private.pem"
-	file, err := os.Create(path.Clean(path.Join(dir, keyName)))
+	keyName := path.Clean(path.Join(dir, "private.pem"))
+	file, err := os.OpenFile(keyName, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
 	if err != nil {
-		return "", errors.Wrapf(err, "failed to create file %s", path.Clean(path.Join(dir, keyName)))
+		return "", errors.Wrapf(err, "failed to create file %s", keyName)
 	}
-	err = pem.Encode(file, privateKeyBlock)
+	defer func() { _ = file.Close() }()
+
+	err = pem.Encode(file, privateKeyPEMBlock(privatekey))
 	if err != nil {
 		return "", errors.Wrap(err, "encode process has failed")
 	}
@@ -594,33 +687,21 @@ func createCertBase64(privatekey *rsa.PrivateKey, isClient bool) (string, error)
 	return base64.StdEncoding.EncodeToString(certbytes), nil
 }
 
-func dumpCertBase64ToFile(base64key, folderName string) (string, error) {
-	dir := path.Join(os.TempDir(), "networkservicemesh", folderName)
-	err := os.MkdirAll(dir, 0o700)
-	if err != nil && !os.IsExist(err) {
-		return "", errors.Wrapf(err, "failed to create directory %s", dir)
-	}
-
-	certbytes, err := base64.StdEncoding.DecodeString(base64key)
-	if err != nil {
-		return "", errors.Wrapf(err, "failed to decode base64 encoded string %s", base64key)
-	}
-
-	publicKeyBlock := &pem.Block{
-		Type:  "CERTIFICATE",
-		Bytes: certbytes,
-	}
-
-	file, err := os.Create(path.Clean(path.Join(dir, "cert.pem")))
+// certPEMBlock - returns the leaf certificate of the base64 encoded DER certificate chain
+func certPEMBlock(base64cert string) (*pem.Block, error) {
+	certbytes, err := base64.StdEncoding.DecodeString(base64cert)
 	if err != nil {
-		return "", errors.Wrapf(err, "failed to create file %s", path.Clean(path.Join(dir, "cert.pem")))
+		return nil, errors.Wrapf(err, "failed to decode base64 encoded string %s", base64cert)
 	}
-	err = pem.Encode(file, publicKeyBlock)
-	if err != nil {
-		return "", errors.Wrap(err, "encode process has failed")
+	certs, err := x509.ParseCertificates(certbytes)
+	if err != nil || len(certs) == 0 {
+		return nil, errors.Errorf("failed to parse certificate %s", base64cert)
 	}
 
-	return file.Name(), nil
+	return &pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: certs[0].Raw,
+	}, nil
 }
 
 func isClientPrefix(isClient bool) string {
@@ -630,7 +711,9 @@ func isClientPrefix(isClient bool) string {
 	return "server"
 }
 
-// GenerateRSAKey generates RSA private key
+// GenerateRSAKey generates RSA private key and stores it in the temporary directory.
+//
+// Deprecated: the file is never removed. Use WithIKEv2RSAPrivateKey to keep the key in memory instead.
 func GenerateRSAKey() (privateKeyFileName string, err error) {
 	var privateKey *rsa.PrivateKey
 	privateKey, err = generateRSAKey()
@@ -16,7 +16,21 @@
 
 package ipsec
 
+import "time"
+
 const (
 	// ikev2DefaultPort - ikev2 default port
 	ikev2DefaultPort = 4500
+
+	// SaLifetime - lifetime of the IKEv2 SAs
+	SaLifetime = time.Hour
+
+	// defaultLivenessPeriod - default period of the IKEv2 liveness (dead peer detection) check
+	defaultLivenessPeriod = 10 * time.Second
+	// defaultLivenessMaxRetries - default number of the failed IKEv2 liveness checks before the SA is deleted
+	defaultLivenessMaxRetries = 3
+
+	// renegotiationTimeout - time the link of the tunnel interface is expected to be down after the IKE SA is
+	// deleted to renegotiate the traffic selectors
+	renegotiationTimeout = time.Minute
 )
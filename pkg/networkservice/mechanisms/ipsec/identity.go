@@ -0,0 +1,247 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	// idTypeFQDN - IKEv2 identification type ID_FQDN
+	idTypeFQDN = 2
+	// idTypeKeyID - IKEv2 identification type ID_KEY_ID, used with X.509-SVIDs
+	idTypeKeyID = 11
+	// keyIDLen - length of the IKEv2 key ID. VPP stores up to 64 bytes of the ID data
+	keyIDLen = 48
+
+	// localKeySubdir - key store subdirectory for the local private key
+	localKeySubdir = "local"
+)
+
+// identity - local IKEv2 credentials of the forwarder and the way peers are authenticated.
+// It either uses a self-signed certificate created from a single RSA key or X.509-SVIDs
+// provided by x509svid.Source and verified against the x509bundle.Source trust bundles.
+type identity struct {
+	vppConn api.Connection
+	keys    *keyStore
+
+	privateKeyFileName string
+	privateKey         *rsa.PrivateKey
+	onceInit           sync.Once
+
+	svidSource   x509svid.Source
+	bundleSource x509bundle.Source
+	authorizer   tlsconfig.Authorizer
+
+	mu         sync.Mutex
+	loadedCert []byte
+	localID    spiffeid.ID
+}
+
+func newIdentity(vppConn api.Connection, opts *ipsecOptions) (*identity, error) {
+	id := &identity{
+		vppConn:      vppConn,
+		keys:         newKeyStore(opts.keyDir, opts.useFIFO),
+		svidSource:   opts.svidSource,
+		bundleSource: opts.bundleSource,
+		authorizer:   opts.authorizer,
+	}
+	if id.authorizer == nil {
+		id.authorizer = tlsconfig.AuthorizeAny()
+	}
+	if id.svidSource != nil {
+		if id.bundleSource == nil {
+			return nil, errors.New("x509bundle.Source is required to verify IKEv2 peer certificates")
+		}
+		return id, nil
+	}
+
+	var err error
+	privateKey := opts.privateKey
+	switch {
+	case privateKey != nil:
+	case opts.privateKeyFileName != "":
+		privateKey, err = privateKeyFromFile(opts.privateKeyFileName)
+	default:
+		privateKey, err = generateRSAKey()
+	}
+	if err != nil {
+		return nil, err
+	}
+	id.privateKeyFileName = opts.privateKeyFileName
+	id.privateKey = privateKey
+	return id, nil
+}
+
+// isX509SVID - returns true if the identity is based on X.509-SVIDs
+func (id *identity) isX509SVID() bool {
+	return id.svidSource != nil
+}
+
+// certificate - makes sure the current local key is loaded into VPP and returns
+// the base64-encoded certificate to be sent to the peer
+func (id *identity) certificate(ctx context.Context, isClient bool) (string, error) {
+	if !id.isX509SVID() {
+		var err error
+		id.onceInit.Do(func() {
+			err = id.setLocalKey(ctx, id.privateKeyFileName, id.privateKey)
+		})
+		if err != nil {
+			return "", err
+		}
+		return createCertBase64(id.privateKey, isClient)
+	}
+
+	svid, err := id.svidSource.GetX509SVID()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get X.509-SVID")
+	}
+	if len(svid.Certificates) == 0 {
+		return "", errors.New("X.509-SVID has no certificates")
+	}
+	leaf := svid.Certificates[0]
+
+	id.mu.Lock()
+	defer id.mu.Unlock()
+
+	// VPP keeps a single local key, so it is reloaded only when the SVID has been rotated
+	if !bytes.Equal(id.loadedCert, leaf.Raw) {
+		privateKey, ok := svid.PrivateKey.(*rsa.PrivateKey)
+		if !ok {
+			return "", errors.Errorf("X.509-SVID %s has unsupported key type %T: IKEv2 in VPP supports RSA keys only", svid.ID, svid.PrivateKey)
+		}
+		if err := id.setLocalKey(ctx, "", privateKey); err != nil {
+			return "", err
+		}
+		log.FromContext(ctx).
+			WithField("spiffeID", svid.ID.String()).
+			WithField("notAfter", leaf.NotAfter).
+			Debug("IKEv2 local key has been reloaded from X.509-SVID")
+		id.loadedCert = leaf.Raw
+		id.localID = svid.ID
+	}
+
+	// The whole chain is sent, so that the peer can verify SVIDs issued by the intermediate CAs
+	var chain []byte
+	for _, cert := range svid.Certificates {
+		chain = append(chain, cert.Raw...)
+	}
+	return base64.StdEncoding.EncodeToString(chain), nil
+}
+
+// setLocalKey - sets IKEv2 local key. The key file provided by the user is passed to VPP as is,
+// otherwise the key is passed through the key store
+func (id *identity) setLocalKey(ctx context.Context, privateKeyFileName string, privateKey *rsa.PrivateKey) error {
+	if privateKeyFileName != "" {
+		return setIKEv2LocalKey(ctx, id.vppConn, privateKeyFileName)
+	}
+	return id.keys.load(ctx, localKeySubdir, privateKeyPEMBlock(privateKey), func(fileName string) error {
+		return setIKEv2LocalKey(ctx, id.vppConn, fileName)
+	})
+}
+
+// ikeIDs - returns IKEv2 identification type and the local and remote identification data.
+// For X.509-SVIDs the peer certificate is verified against the trust bundle, checked by authorizer and
+// its SPIFFE ID must belong to one of the path segments of the connection.
+func (id *identity) ikeIDs(conn *networkservice.Connection, mechanism *ipsec.Mechanism, isClient bool) (idType uint8, local, remote string, err error) {
+	localCert, peerCert := mechanism.SrcPublicKey(), mechanism.DstPublicKey()
+	if !isClient {
+		localCert, peerCert = peerCert, localCert
+	}
+
+	if !id.isX509SVID() {
+		// We need unique values per client/server. Using public keys
+		return idTypeFQDN, localCert[:64], peerCert[:64], nil
+	}
+
+	peerID, err := id.verifyPeer(conn, peerCert)
+	if err != nil {
+		return 0, "", "", err
+	}
+
+	id.mu.Lock()
+	localID := id.localID
+	id.mu.Unlock()
+
+	return idTypeKeyID, connectionKeyID(conn, localID), connectionKeyID(conn, peerID), nil
+}
+
+// connectionKeyID - returns IKEv2 key ID unique per connection. SVIDs are shared by all the connections
+// of the forwarder, so SPIFFE IDs can't be used as is: VPP selects the responder profile by the remote ID.
+// The first path segment ID is the same on both sides of the connection.
+func connectionKeyID(conn *networkservice.Connection, spiffeID spiffeid.ID) string {
+	var firstSegmentID string
+	if segments := conn.GetPath().GetPathSegments(); len(segments) > 0 {
+		firstSegmentID = segments[0].GetId()
+	}
+	sum := sha256.Sum256([]byte(spiffeID.String() + "/" + firstSegmentID))
+	return hex.EncodeToString(sum[:])[:keyIDLen]
+}
+
+func (id *identity) verifyPeer(conn *networkservice.Connection, peerCertBase64 string) (spiffeid.ID, error) {
+	certBytes, err := base64.StdEncoding.DecodeString(peerCertBase64)
+	if err != nil {
+		return spiffeid.ID{}, errors.Wrap(err, "failed to decode peer certificate")
+	}
+	certs, err := x509.ParseCertificates(certBytes)
+	if err != nil {
+		return spiffeid.ID{}, errors.Wrap(err, "failed to parse peer certificate")
+	}
+	peerID, chains, err := x509svid.Verify(certs, id.bundleSource)
+	if err != nil {
+		return spiffeid.ID{}, errors.Wrap(err, "failed to verify peer certificate")
+	}
+	if err := id.authorizer(peerID, chains); err != nil {
+		return spiffeid.ID{}, errors.Wrapf(err, "peer %s is not authorized", peerID)
+	}
+	if !isInPath(conn.GetPath(), peerID) {
+		return spiffeid.ID{}, errors.Errorf("peer %s is not a part of the connection path", peerID)
+	}
+	return peerID, nil
+}
+
+func isInPath(path *networkservice.Path, peerID spiffeid.ID) bool {
+	for _, segment := range path.GetPathSegments() {
+		claims := jwt.RegisteredClaims{}
+		if _, _, err := jwt.NewParser().ParseUnverified(segment.GetToken(), &claims); err != nil {
+			continue
+		}
+		if claims.Subject == peerID.String() {
+			return true
+		}
+	}
+	return false
+}
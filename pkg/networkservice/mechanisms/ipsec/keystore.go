@@ -0,0 +1,141 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"context"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// keyStore - passes key material to VPP through the files in the dedicated directory.
+// The files are readable by the owner only and exist just for the time VPP needs to load them.
+type keyStore struct {
+	dir     string
+	useFIFO bool
+}
+
+func newKeyStore(dir string, useFIFO bool) *keyStore {
+	return &keyStore{
+		dir:     keyDir(dir),
+		useFIFO: useFIFO,
+	}
+}
+
+func keyDir(dir string) string {
+	if dir == "" {
+		return filepath.Join(os.TempDir(), "networkservicemesh", "ipsec")
+	}
+	return dir
+}
+
+// SweepKeyDir - removes the private key files left in the directory (e.g. after the forwarder crash). Only the *.pem
+// files of the local key subdirectory are removed, the rest of the directory is left untouched.
+// Must be called before any ipsec chain element using the directory starts handling requests.
+// Empty dir means the default directory.
+func SweepKeyDir(dir string) error {
+	fileNames, err := filepath.Glob(filepath.Join(keyDir(dir), localKeySubdir, "*.pem"))
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the key files of %s", dir)
+	}
+	for _, fileName := range fileNames {
+		if err := os.Remove(fileName); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to remove %s", fileName)
+		}
+	}
+	return nil
+}
+
+// load - writes PEM encoded block to the file in the subdirectory and calls loadFn with the file name.
+// loadFn is expected to make VPP read the file. The file is removed as soon as loadFn returns.
+func (k *keyStore) load(ctx context.Context, subdir string, block *pem.Block, loadFn func(fileName string) error) error {
+	dir := filepath.Join(k.dir, subdir)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return errors.Wrapf(err, "failed to create directory %s", dir)
+	}
+	fileName := filepath.Join(dir, uuid.New().String()+".pem")
+	defer func() {
+		if err := os.Remove(fileName); err != nil && !os.IsNotExist(err) {
+			log.FromContext(ctx).Warnf("failed to remove %s: %v", fileName, err)
+		}
+	}()
+
+	if k.useFIFO {
+		return k.loadFIFO(fileName, block, loadFn)
+	}
+
+	file, err := os.OpenFile(filepath.Clean(fileName), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create file %s", fileName)
+	}
+	err = pem.Encode(file, block)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrap(err, "encode process has failed")
+	}
+	return loadFn(fileName)
+}
+
+// loadFIFO - passes PEM encoded block to VPP through the named pipe, so the key material is never stored on disk
+func (k *keyStore) loadFIFO(fileName string, block *pem.Block, loadFn func(fileName string) error) error {
+	if err := unix.Mkfifo(fileName, 0o600); err != nil {
+		return errors.Wrapf(err, "failed to create named pipe %s", fileName)
+	}
+
+	writeErrCh := make(chan error, 1)
+	go func() {
+		// Blocks until VPP opens the pipe for reading
+		file, err := os.OpenFile(filepath.Clean(fileName), os.O_WRONLY, 0)
+		if err != nil {
+			writeErrCh <- errors.Wrapf(err, "failed to open named pipe %s", fileName)
+			return
+		}
+		err = pem.Encode(file, block)
+		_ = file.Close()
+		writeErrCh <- errors.Wrap(err, "encode process has failed")
+	}()
+
+	loadErr := loadFn(fileName)
+
+	// If VPP hasn't read the pipe, the writer is still waiting for a reader. Keep the pipe open
+	// for reading until the writer is done to release it
+	reader, err := os.OpenFile(filepath.Clean(fileName), os.O_RDONLY|unix.O_NONBLOCK, 0)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open named pipe %s", fileName)
+	}
+	writeErr := <-writeErrCh
+	_ = reader.Close()
+
+	if loadErr != nil {
+		return loadErr
+	}
+	return writeErr
+}
+
+// remove - removes all the files stored in the subdirectory
+func (k *keyStore) remove(subdir string) error {
+	return os.RemoveAll(filepath.Join(k.dir, subdir))
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"context"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type peerCertKey struct{}
+type trafficSelectorsKeyKey struct{}
+type ikeIDsKey struct{}
+type renegotiationKey struct{}
+
+// ikeIDs - the local and remote IDs configured in the IKEv2 profile
+type ikeIDs struct {
+	local  string
+	remote string
+}
+
+// storePeerCert sets the peer certificate configured in the IKEv2 profile stored in per Connection.Id metadata.
+func storePeerCert(ctx context.Context, isClient bool, cert string) {
+	metadata.Map(ctx, isClient).Store(peerCertKey{}, cert)
+}
+
+// deletePeerCert deletes the peer certificate stored in per Connection.Id metadata
+func deletePeerCert(ctx context.Context, isClient bool) {
+	metadata.Map(ctx, isClient).Delete(peerCertKey{})
+}
+
+// loadPeerCert returns the peer certificate stored in per Connection.Id metadata.
+// The ok result indicates whether value was found in the per Connection.Id metadata.
+func loadPeerCert(ctx context.Context, isClient bool) (value string, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(peerCertKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(string)
+	return value, ok
+}
+
+// storeTrafficSelectorsKey sets the key of the traffic selectors configured in the IKEv2 profile stored in per Connection.Id metadata.
+func storeTrafficSelectorsKey(ctx context.Context, isClient bool, key string) {
+	metadata.Map(ctx, isClient).Store(trafficSelectorsKeyKey{}, key)
+}
+
+// deleteTrafficSelectorsKey deletes the key of the traffic selectors stored in per Connection.Id metadata
+func deleteTrafficSelectorsKey(ctx context.Context, isClient bool) {
+	metadata.Map(ctx, isClient).Delete(trafficSelectorsKeyKey{})
+}
+
+// loadTrafficSelectorsKey returns the key of the traffic selectors stored in per Connection.Id metadata.
+// The ok result indicates whether value was found in the per Connection.Id metadata.
+func loadTrafficSelectorsKey(ctx context.Context, isClient bool) (value string, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(trafficSelectorsKeyKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(string)
+	return value, ok
+}
+
+// storeIkeIDs sets the IDs configured in the IKEv2 profile stored in per Connection.Id metadata.
+func storeIkeIDs(ctx context.Context, isClient bool, ids *ikeIDs) {
+	metadata.Map(ctx, isClient).Store(ikeIDsKey{}, ids)
+}
+
+// deleteIkeIDs deletes the IDs stored in per Connection.Id metadata
+func deleteIkeIDs(ctx context.Context, isClient bool) {
+	metadata.Map(ctx, isClient).Delete(ikeIDsKey{})
+}
+
+// loadIkeIDs returns the IDs stored in per Connection.Id metadata.
+// The ok result indicates whether value was found in the per Connection.Id metadata.
+func loadIkeIDs(ctx context.Context, isClient bool) (value *ikeIDs, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(ikeIDsKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*ikeIDs)
+	return value, ok
+}
+
+// storeRenegotiation marks the IKE SA of the connection deleted to renegotiate the traffic selectors
+// in per Connection.Id metadata.
+func storeRenegotiation(ctx context.Context, isClient bool) {
+	metadata.Map(ctx, isClient).Store(renegotiationKey{}, time.Now().Add(renegotiationTimeout))
+}
+
+// DeleteRenegotiation marks the renegotiation of the IKE SA finished in per Connection.Id metadata
+func DeleteRenegotiation(ctx context.Context, isClient bool) {
+	metadata.Map(ctx, isClient).Delete(renegotiationKey{})
+}
+
+// IsRenegotiating returns true if the IKE SA of the connection is being renegotiated, so the link of the tunnel
+// interface is expected to go down. The renegotiation is considered failed after the timeout.
+func IsRenegotiating(ctx context.Context, isClient bool) bool {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(renegotiationKey{})
+	if !ok {
+		return false
+	}
+	deadline, ok := rawValue.(time.Time)
+	return ok && time.Now().Before(deadline)
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2023 Cisco and/or its affiliates.
+// Copyright (c) 2023-2024 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -16,8 +16,25 @@
 
 package ipsec
 
+import (
+	"crypto/rsa"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+)
+
 type ipsecOptions struct {
 	privateKeyFileName string
+	privateKey         *rsa.PrivateKey
+	keyDir             string
+	useFIFO            bool
+	svidSource         x509svid.Source
+	bundleSource       x509bundle.Source
+	authorizer         tlsconfig.Authorizer
+	livenessPeriod     time.Duration
+	livenessMaxRetries uint32
 }
 
 // Option is an option pattern for ipsec chain element
@@ -29,3 +46,57 @@ func WithIKEv2PrivateKey(privateKeyFileName string) Option {
 		o.privateKeyFileName = privateKeyFileName
 	}
 }
+
+// WithIKEv2RSAPrivateKey - sets private key. The key is passed to VPP without being stored on disk permanently
+func WithIKEv2RSAPrivateKey(privateKey *rsa.PrivateKey) Option {
+	return func(o *ipsecOptions) {
+		o.privateKey = privateKey
+	}
+}
+
+// WithKeyDir - sets the directory for the files the key material is passed to VPP through.
+// The directory must be accessible by VPP. The files are created with 0600 permissions and
+// removed as soon as VPP has loaded them.
+// Default: $TMPDIR/networkservicemesh/ipsec
+func WithKeyDir(dir string) Option {
+	return func(o *ipsecOptions) {
+		o.keyDir = dir
+	}
+}
+
+// WithFIFOKeyPassing - passes the key material to VPP through named pipes instead of regular files,
+// so it is never stored on disk. Requires VPP to read the key files sequentially (as VPP using OpenSSL file BIO does).
+func WithFIFOKeyPassing() Option {
+	return func(o *ipsecOptions) {
+		o.useFIFO = true
+	}
+}
+
+// WithX509Source - sets the source of X.509-SVIDs used for IKEv2 certificate authentication and
+// the source of trust bundles the peer certificates are verified against.
+// The SVID is reloaded into VPP when it is rotated. VPP supports RSA keys only.
+// Overrides WithIKEv2PrivateKey.
+func WithX509Source(svidSource x509svid.Source, bundleSource x509bundle.Source) Option {
+	return func(o *ipsecOptions) {
+		o.svidSource = svidSource
+		o.bundleSource = bundleSource
+	}
+}
+
+// WithPeerAuthorizer - sets the authorizer for SPIFFE IDs of the IKEv2 peers (used with WithX509Source).
+// Default: tlsconfig.AuthorizeAny()
+func WithPeerAuthorizer(authorizer tlsconfig.Authorizer) Option {
+	return func(o *ipsecOptions) {
+		o.authorizer = authorizer
+	}
+}
+
+// WithLiveness - sets IKEv2 liveness (dead peer detection) check period and the number of failed checks after which
+// the SA is deleted and the tunnel interface goes down. The settings are global for VPP.
+// Default: 10s, 3 retries
+func WithLiveness(period time.Duration, maxRetries uint32) Option {
+	return func(o *ipsecOptions) {
+		o.livenessPeriod = period
+		o.livenessMaxRetries = maxRetries
+	}
+}
@@ -18,16 +18,15 @@ package ipsec
 
 import (
 	"context"
-	"crypto/rsa"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.fd.io/govpp/api"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
-	ipsecMech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
@@ -42,39 +41,37 @@ import (
 type ipsecServer struct {
 	vppConn  api.Connection
 	tunnelIP net.IP
+	identity *identity
 
-	privateKeyFileName string
-	privateKey         *rsa.PrivateKey
-	onceInit           sync.Once
+	livenessPeriod     time.Duration
+	livenessMaxRetries uint32
+	livenessOnce       sync.Once
 }
 
 // NewServer - returns a new server for the IPSec remote mechanism
 func NewServer(vppConn api.Connection, tunnelIP net.IP, options ...Option) networkservice.NetworkServiceServer {
-	opts := &ipsecOptions{}
+	opts := &ipsecOptions{
+		livenessPeriod:     defaultLivenessPeriod,
+		livenessMaxRetries: defaultLivenessMaxRetries,
+	}
 	for _, opt := range options {
 		opt(opts)
 	}
 
-	if opts.privateKeyFileName == "" {
-		var err error
-		opts.privateKeyFileName, err = GenerateRSAKey()
-		if err != nil {
-			log.FromContext(context.Background()).Fatalf("ipsecServer GenerateRSAKey error: %v", err)
-		}
-	}
-
-	privateKey, err := privateKeyFromFile(opts.privateKeyFileName)
+	id, err := newIdentity(vppConn, opts)
 	if err != nil {
-		log.FromContext(context.Background()).Fatalf("ipsecServer privateKeyFromFile error: %v", err)
+		log.FromContext(context.Background()).Fatalf("ipsecServer identity error: %v", err)
 	}
 
 	return chain.NewNetworkServiceServer(
 		mtu.NewServer(vppConn, tunnelIP),
 		&ipsecServer{
-			vppConn:            vppConn,
-			tunnelIP:           tunnelIP,
-			privateKeyFileName: opts.privateKeyFileName,
-			privateKey:         privateKey,
+			vppConn:  vppConn,
+			tunnelIP: tunnelIP,
+			identity: id,
+
+			livenessPeriod:     opts.livenessPeriod,
+			livenessMaxRetries: opts.livenessMaxRetries,
 		},
 	)
 }
@@ -84,14 +81,14 @@ func (i *ipsecServer) Request(ctx context.Context, request *networkservice.Netwo
 		return next.Server(ctx).Request(ctx, request)
 	}
 	var err error
-	i.onceInit.Do(func() {
-		err = setIKEv2LocalKey(ctx, i.vppConn, i.privateKeyFileName)
+	i.livenessOnce.Do(func() {
+		err = setLiveness(ctx, i.vppConn, i.livenessPeriod, i.livenessMaxRetries)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	if mechanism := ipsecMech.ToMechanism(request.GetConnection().GetMechanism()); mechanism != nil {
+	if mechanism := toMechanism(request.GetConnection().GetMechanism()); mechanism != nil {
 		mechanism.SetDstIP(i.tunnelIP)
 		mechanism.SetDstPort(ikev2DefaultPort)
 
@@ -106,14 +103,14 @@ func (i *ipsecServer) Request(ctx context.Context, request *networkservice.Netwo
 		return nil, err
 	}
 
-	if mechanism := ipsecMech.ToMechanism(conn.GetMechanism()); mechanism != nil {
-		certificate, err := createCertBase64(i.privateKey, metadata.IsClient(i))
+	if mechanism := toMechanism(conn.GetMechanism()); mechanism != nil {
+		certificate, err := i.identity.certificate(ctx, metadata.IsClient(i))
 		if err != nil {
 			return nil, err
 		}
 		mechanism.SetDstPublicKey(certificate)
 
-		err = create(ctx, conn, i.vppConn, metadata.IsClient(i))
+		err = create(ctx, conn, i.vppConn, i.identity, metadata.IsClient(i))
 		if err != nil {
 			closeCtx, cancelClose := postponeCtxFunc()
 			defer cancelClose()
@@ -130,8 +127,8 @@ func (i *ipsecServer) Request(ctx context.Context, request *networkservice.Netwo
 }
 
 func (i *ipsecServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
-	if mechanism := ipsecMech.ToMechanism(conn.GetMechanism()); mechanism != nil {
-		delInterface(ctx, conn, i.vppConn, metadata.IsClient(i))
+	if mechanism := toMechanism(conn.GetMechanism()); mechanism != nil {
+		delInterface(ctx, conn, i.vppConn, i.identity.keys, metadata.IsClient(i))
 	}
 	return next.Server(ctx).Close(ctx, conn)
 }
@@ -0,0 +1,137 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staticsa
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	ipsecMech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/ipsec/mtu"
+)
+
+type staticSAClient struct {
+	vppConn       api.Connection
+	tunnelIP      net.IP
+	rekeyInterval time.Duration
+}
+
+// NewClient - returns a new client for the ipsec mechanism with manually keyed ESP SAs
+func NewClient(vppConn api.Connection, tunnelIP net.IP, options ...Option) networkservice.NetworkServiceClient {
+	opts := &staticSAOptions{
+		rekeyInterval: defaultRekeyInterval,
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	return chain.NewNetworkServiceClient(
+		&staticSAClient{
+			vppConn:       vppConn,
+			tunnelIP:      tunnelIP,
+			rekeyInterval: opts.rekeyInterval,
+		},
+		mtu.NewClient(vppConn, tunnelIP),
+	)
+}
+
+func (s *staticSAClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	if request.GetConnection().GetPayload() != payload.IP {
+		return next.Client(ctx).Request(ctx, request, opts...)
+	}
+
+	keys, err := s.keys(ctx, request.GetConnection().GetMechanism())
+	if err != nil {
+		return nil, err
+	}
+	// The selected mechanism is sent as is on the refresh, so the new keys must be set there as well
+	if IsStatic(request.GetConnection().GetMechanism()) {
+		keys.toParameters(request.GetConnection().GetMechanism(), SrcSPIKey, SrcKeyKey)
+	}
+
+	mechanism := &networkservice.Mechanism{
+		Cls:  cls.REMOTE,
+		Type: ipsecMech.MECHANISM,
+		Parameters: map[string]string{
+			ModeKey: ModeStatic,
+		},
+	}
+	ipsecMech.ToMechanism(mechanism).
+		SetSrcIP(s.tunnelIP).
+		SetSrcPort(espUDPPort)
+	keys.toParameters(mechanism, SrcSPIKey, SrcKeyKey)
+
+	request.MechanismPreferences = append(request.MechanismPreferences, mechanism)
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = create(ctx, conn, s.vppConn, metadata.IsClient(s)); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := s.Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *staticSAClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if IsStatic(conn.GetMechanism()) {
+		delInterface(ctx, s.vppConn, metadata.IsClient(s))
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+// keys - returns the keys of the client outbound SA. The keys of the established connection are reused
+// until the rekey interval has passed since they have been programmed
+func (s *staticSAClient) keys(ctx context.Context, mechanism *networkservice.Mechanism) (*saKeys, error) {
+	if !IsStatic(mechanism) {
+		return generateKeys()
+	}
+	keys, err := keysFromParameters(mechanism, SrcSPIKey, SrcKeyKey)
+	if err != nil || keys == nil {
+		return generateKeys()
+	}
+	state, ok := load(ctx, metadata.IsClient(s))
+	if !ok || state.outSPI != keys.spi || time.Since(state.outAddedAt) >= s.rekeyInterval {
+		return generateKeys()
+	}
+	return keys, nil
+}
@@ -0,0 +1,280 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staticsa
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"net"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	ipsecapi "github.com/networkservicemesh/govpp/binapi/ipsec"
+	"github.com/networkservicemesh/govpp/binapi/ipsec_types"
+	"github.com/networkservicemesh/govpp/binapi/tunnel_types"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+// create - creates the IPSec interface and programs the ESP SAs from the mechanism parameters.
+// On the rekey the new SAs are added and the tunnel protection is switched to them before the old ones are deleted.
+func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
+	if !IsStatic(conn.GetMechanism()) {
+		return nil
+	}
+	mechanism := ipsec.ToMechanism(conn.GetMechanism())
+
+	localKeys, err := keysFromParameters(conn.GetMechanism(), SrcSPIKey, SrcKeyKey)
+	if err != nil {
+		return err
+	}
+	peerKeys, err := keysFromParameters(conn.GetMechanism(), DstSPIKey, DstKeyKey)
+	if err != nil {
+		return err
+	}
+	localIP, peerIP := mechanism.SrcIP(), mechanism.DstIP()
+	if !isClient {
+		localKeys, peerKeys = peerKeys, localKeys
+		localIP, peerIP = peerIP, localIP
+	}
+	if localKeys == nil || peerKeys == nil {
+		return errors.New("ipsec mechanism has no ESP keys")
+	}
+
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		swIfIndex, err = createIPSecTunnel(ctx, vppConn)
+		if err != nil {
+			return err
+		}
+		ifindex.Store(ctx, isClient, swIfIndex)
+	}
+
+	state, ok := load(ctx, isClient)
+	if !ok {
+		state = &saState{}
+		store(ctx, isClient, state)
+	}
+	updated := false
+	if state.outID == 0 || state.outSPI != localKeys.spi {
+		saID, err := addSA(ctx, vppConn, localKeys, localIP, peerIP, false)
+		if err != nil {
+			return err
+		}
+		if state.outID != 0 {
+			state.staleIDs = append(state.staleIDs, state.outID)
+		}
+		state.outID, state.outSPI, state.outAddedAt = saID, localKeys.spi, time.Now()
+		updated = true
+	}
+	if len(state.inIDs) == 0 || state.inSPI != peerKeys.spi {
+		saID, err := addSA(ctx, vppConn, peerKeys, peerIP, localIP, true)
+		if err != nil {
+			return err
+		}
+		if len(state.inIDs) > 1 {
+			state.staleIDs = append(state.staleIDs, state.inIDs[1:]...)
+			state.inIDs = state.inIDs[:1]
+		}
+		state.inIDs = append([]uint32{saID}, state.inIDs...)
+		state.inSPI = peerKeys.spi
+		updated = true
+	}
+
+	if !updated {
+		return nil
+	}
+	if err := protectTunnel(ctx, vppConn, swIfIndex, state); err != nil {
+		return err
+	}
+	for _, saID := range state.staleIDs {
+		if err := delSA(ctx, vppConn, saID); err != nil {
+			log.FromContext(ctx).Warnf("failed to delete stale SA %d: %v", saID, err)
+		}
+	}
+	state.staleIDs = nil
+	return nil
+}
+
+func createIPSecTunnel(ctx context.Context, vppConn api.Connection) (interface_types.InterfaceIndex, error) {
+	now := time.Now()
+	reply, err := ipsecapi.NewServiceClient(vppConn).IpsecItfCreate(ctx, &ipsecapi.IpsecItfCreate{
+		Itf: ipsecapi.IpsecItf{UserInstance: ^uint32(0)}})
+	if err != nil {
+		return interface_types.InterfaceIndex(^uint32(0)), errors.Wrap(err, "vppapi IpsecItfCreate returned error")
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", reply.SwIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IpsecItfCreate").Debug("completed")
+	return reply.SwIfIndex, nil
+}
+
+func delIPSecTunnel(ctx context.Context, vppConn api.Connection, isClient bool) error {
+	swIfIndex, ok := ifindex.LoadAndDelete(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	now := time.Now()
+	if _, err := ipsecapi.NewServiceClient(vppConn).IpsecItfDelete(ctx, &ipsecapi.IpsecItfDelete{SwIfIndex: swIfIndex}); err != nil {
+		return errors.Wrap(err, "vppapi IpsecItfDelete returned error")
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IpsecItfDelete").Debug("completed")
+	return nil
+}
+
+// addSA - adds the tunnel mode AES-GCM-256 ESP SA with UDP encapsulation and returns its ID
+func addSA(ctx context.Context, vppConn api.Connection, keys *saKeys, srcIP, dstIP net.IP, isInbound bool) (uint32, error) {
+	saID, err := newSAID()
+	if err != nil {
+		return 0, err
+	}
+	flags := ipsec_types.IPSEC_API_SAD_FLAG_IS_TUNNEL | ipsec_types.IPSEC_API_SAD_FLAG_UDP_ENCAP
+	if srcIP.To4() == nil {
+		flags |= ipsec_types.IPSEC_API_SAD_FLAG_IS_TUNNEL_V6
+	}
+	if isInbound {
+		flags |= ipsec_types.IPSEC_API_SAD_FLAG_IS_INBOUND | ipsec_types.IPSEC_API_SAD_FLAG_USE_ANTI_REPLAY
+	}
+
+	now := time.Now()
+	_, err = ipsecapi.NewServiceClient(vppConn).IpsecSadEntryAdd(ctx, &ipsecapi.IpsecSadEntryAdd{
+		Entry: ipsec_types.IpsecSadEntryV3{
+			SadID:           saID,
+			Spi:             keys.spi,
+			Protocol:        ipsec_types.IPSEC_API_PROTO_ESP,
+			CryptoAlgorithm: ipsec_types.IPSEC_API_CRYPTO_ALG_AES_GCM_256,
+			CryptoKey: ipsec_types.Key{
+				Length: uint8(len(keys.key)),
+				Data:   keys.key,
+			},
+			IntegrityAlgorithm: ipsec_types.IPSEC_API_INTEG_ALG_NONE,
+			Flags:              flags,
+			Tunnel: tunnel_types.Tunnel{
+				Src:       types.ToVppAddress(srcIP),
+				Dst:       types.ToVppAddress(dstIP),
+				SwIfIndex: interface_types.InterfaceIndex(^uint32(0)),
+			},
+			Salt:       keys.salt,
+			UDPSrcPort: espUDPPort,
+			UDPDstPort: espUDPPort,
+		},
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "vppapi IpsecSadEntryAdd returned error")
+	}
+	log.FromContext(ctx).
+		WithField("SadID", saID).
+		WithField("Spi", keys.spi).
+		WithField("isInbound", isInbound).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IpsecSadEntryAdd").Debug("completed")
+	return saID, nil
+}
+
+func delSA(ctx context.Context, vppConn api.Connection, saID uint32) error {
+	now := time.Now()
+	if _, err := ipsecapi.NewServiceClient(vppConn).IpsecSadEntryDel(ctx, &ipsecapi.IpsecSadEntryDel{ID: saID}); err != nil {
+		return errors.Wrap(err, "vppapi IpsecSadEntryDel returned error")
+	}
+	log.FromContext(ctx).
+		WithField("SadID", saID).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IpsecSadEntryDel").Debug("completed")
+	return nil
+}
+
+func protectTunnel(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, state *saState) error {
+	now := time.Now()
+	_, err := ipsecapi.NewServiceClient(vppConn).IpsecTunnelProtectUpdate(ctx, &ipsecapi.IpsecTunnelProtectUpdate{
+		Tunnel: ipsecapi.IpsecTunnelProtect{
+			SwIfIndex: swIfIndex,
+			SaOut:     state.outID,
+			NSaIn:     uint8(len(state.inIDs)),
+			SaIn:      state.inIDs,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "vppapi IpsecTunnelProtectUpdate returned error")
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("SaOut", state.outID).
+		WithField("SaIn", state.inIDs).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IpsecTunnelProtectUpdate").Debug("completed")
+	return nil
+}
+
+func unprotectTunnel(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) error {
+	now := time.Now()
+	if _, err := ipsecapi.NewServiceClient(vppConn).IpsecTunnelProtectDel(ctx, &ipsecapi.IpsecTunnelProtectDel{SwIfIndex: swIfIndex}); err != nil {
+		return errors.Wrap(err, "vppapi IpsecTunnelProtectDel returned error")
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IpsecTunnelProtectDel").Debug("completed")
+	return nil
+}
+
+// delInterface - deletes the IPSec interface and the ESP SAs of the connection
+func delInterface(ctx context.Context, vppConn api.Connection, isClient bool) {
+	if swIfIndex, ok := ifindex.Load(ctx, isClient); ok {
+		if err := unprotectTunnel(ctx, vppConn, swIfIndex); err != nil {
+			log.FromContext(ctx).Warnf("failed to remove tunnel protection: %v", err)
+		}
+	}
+	if state, ok := loadAndDelete(ctx, isClient); ok {
+		saIDs := append(append([]uint32{state.outID}, state.inIDs...), state.staleIDs...)
+		for _, saID := range saIDs {
+			if saID == 0 {
+				continue
+			}
+			if err := delSA(ctx, vppConn, saID); err != nil {
+				log.FromContext(ctx).Warnf("failed to delete SA %d: %v", saID, err)
+			}
+		}
+	}
+	if err := delIPSecTunnel(ctx, vppConn, isClient); err != nil {
+		log.FromContext(ctx).Warnf("failed to delete IPSec interface: %v", err)
+	}
+}
+
+// newSAID - returns random non-zero SA ID. VPP SA IDs are chosen by the API user. The IKEv2 plugin
+// uses the IDs with the top bit set, so the lower half of the range is used to avoid the conflicts
+func newSAID() (uint32, error) {
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		return 0, errors.Wrap(err, "failed to generate SA ID")
+	}
+	saID := binary.BigEndian.Uint32(buf) &^ (1 << 31)
+	if saID == 0 {
+		saID = 1
+	}
+	return saID, nil
+}
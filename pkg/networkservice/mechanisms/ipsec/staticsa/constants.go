@@ -0,0 +1,41 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staticsa
+
+import "time"
+
+const (
+	// ModeKey - ipsec mechanism parameter key for the ipsec mode
+	ModeKey = "ipsec_mode"
+	// ModeStatic - ipsec mode with manually keyed ESP SAs
+	ModeStatic = "static"
+
+	// SrcSPIKey - ipsec mechanism parameter key for the SPI of the client outbound SA
+	SrcSPIKey = "src_spi"
+	// SrcKeyKey - ipsec mechanism parameter key for the hex-encoded key and salt of the client outbound SA
+	SrcKeyKey = "src_key"
+	// DstSPIKey - ipsec mechanism parameter key for the SPI of the server outbound SA
+	DstSPIKey = "dst_spi"
+	// DstKeyKey - ipsec mechanism parameter key for the hex-encoded key and salt of the server outbound SA
+	DstKeyKey = "dst_key"
+
+	// espUDPPort - UDP port of the encapsulated ESP traffic
+	espUDPPort = 4500
+
+	// defaultRekeyInterval - default interval after which the client regenerates the keys on the connection refresh
+	defaultRekeyInterval = time.Hour
+)
@@ -0,0 +1,21 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package staticsa provides networkservice.NetworkService{Client,Server} chain elements for the ipsec mechanism
+// with manually keyed ESP SAs. No IKEv2 is involved: the keys are generated by each side for its outbound SA
+// and exchanged in the mechanism parameters over the mTLS-protected control plane (the same way wireguard
+// exchanges public keys). The client rekeys on the connection refresh, the server follows.
+package staticsa
@@ -0,0 +1,99 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staticsa
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+)
+
+const (
+	// keyLen - length of the AES-GCM-256 key
+	keyLen = 32
+	// saltLen - length of the AES-GCM salt
+	saltLen = 4
+	// minSPI - SPI values below 256 are reserved (RFC 4303)
+	minSPI = 256
+)
+
+// saKeys - SPI and key material of the ESP SA
+type saKeys struct {
+	spi  uint32
+	key  []byte
+	salt uint32
+}
+
+// IsStatic - returns true if the mechanism is the ipsec mechanism with manually keyed ESP SAs
+func IsStatic(mechanism *networkservice.Mechanism) bool {
+	return ipsec.ToMechanism(mechanism) != nil && mechanism.GetParameters()[ModeKey] == ModeStatic
+}
+
+func generateKeys() (*saKeys, error) {
+	buf := make([]byte, 4+keyLen+saltLen)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, errors.Wrap(err, "failed to generate ESP key material")
+	}
+	spi := binary.BigEndian.Uint32(buf)
+	if spi < minSPI {
+		spi += minSPI
+	}
+	return &saKeys{
+		spi:  spi,
+		key:  buf[4 : 4+keyLen],
+		salt: binary.BigEndian.Uint32(buf[4+keyLen:]),
+	}, nil
+}
+
+// keysFromParameters - returns the keys stored in the mechanism parameters or nil if there are no keys
+func keysFromParameters(mechanism *networkservice.Mechanism, spiKey, keyKey string) (*saKeys, error) {
+	params := mechanism.GetParameters()
+	if params[spiKey] == "" || params[keyKey] == "" {
+		return nil, nil
+	}
+	spi, err := strconv.ParseUint(params[spiKey], 10, 32)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s parameter", spiKey)
+	}
+	keyAndSalt, err := hex.DecodeString(params[keyKey])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s parameter", keyKey)
+	}
+	if len(keyAndSalt) != keyLen+saltLen {
+		return nil, errors.Errorf("invalid %s parameter: expected %d bytes, got %d", keyKey, keyLen+saltLen, len(keyAndSalt))
+	}
+	return &saKeys{
+		spi:  uint32(spi),
+		key:  keyAndSalt[:keyLen],
+		salt: binary.BigEndian.Uint32(keyAndSalt[keyLen:]),
+	}, nil
+}
+
+func (k *saKeys) toParameters(mechanism *networkservice.Mechanism, spiKey, keyKey string) {
+	if mechanism.Parameters == nil {
+		mechanism.Parameters = make(map[string]string)
+	}
+	mechanism.Parameters[spiKey] = strconv.FormatUint(uint64(k.spi), 10)
+	keyAndSalt := binary.BigEndian.AppendUint32(append([]byte{}, k.key...), k.salt)
+	mechanism.Parameters[keyKey] = hex.EncodeToString(keyAndSalt)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staticsa
+
+import (
+	"context"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type key struct{}
+
+// saState - ESP SAs programmed for the connection
+type saState struct {
+	outID  uint32
+	outSPI uint32
+	// outAddedAt - time the current outbound SA has been added, used to schedule the client rekey
+	outAddedAt time.Time
+	// inIDs - inbound SAs, the current one goes first. The previous one is kept until the next rekey
+	// to receive the packets sent by the peer before it has switched to the new keys
+	inIDs []uint32
+	inSPI uint32
+	// staleIDs - replaced SAs to be deleted as soon as the tunnel protection is switched to the new ones
+	staleIDs []uint32
+}
+
+// store sets the SA state stored in per Connection.Id metadata.
+func store(ctx context.Context, isClient bool, state *saState) {
+	metadata.Map(ctx, isClient).Store(key{}, state)
+}
+
+// loadAndDelete deletes the SA state stored in per Connection.Id metadata,
+// returning the previous value if any. The loaded result reports whether the key was present.
+func loadAndDelete(ctx context.Context, isClient bool) (value *saState, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*saState)
+	return value, ok
+}
+
+// load returns the SA state stored in per Connection.Id metadata.
+// The ok result indicates whether value was found in the per Connection.Id metadata.
+func load(ctx context.Context, isClient bool) (value *saState, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*saState)
+	return value, ok
+}
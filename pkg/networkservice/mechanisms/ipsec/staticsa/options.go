@@ -0,0 +1,35 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staticsa
+
+import "time"
+
+type staticSAOptions struct {
+	rekeyInterval time.Duration
+}
+
+// Option is an option pattern for staticsa chain elements
+type Option func(o *staticSAOptions)
+
+// WithRekeyInterval - sets the interval after which the client generates new keys on the connection refresh.
+// The server generates new keys each time the client does.
+// Default: 1h
+func WithRekeyInterval(rekeyInterval time.Duration) Option {
+	return func(o *staticSAOptions) {
+		o.rekeyInterval = rekeyInterval
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staticsa
+
+import (
+	"context"
+	"net"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	ipsecMech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/ipsec/mtu"
+)
+
+type staticSAServer struct {
+	vppConn  api.Connection
+	tunnelIP net.IP
+}
+
+// NewServer - returns a new server for the ipsec mechanism with manually keyed ESP SAs.
+// The ipsec mechanisms of other modes are passed to the next chain element as is
+func NewServer(vppConn api.Connection, tunnelIP net.IP) networkservice.NetworkServiceServer {
+	return chain.NewNetworkServiceServer(
+		mtu.NewServer(vppConn, tunnelIP),
+		&staticSAServer{
+			vppConn:  vppConn,
+			tunnelIP: tunnelIP,
+		},
+	)
+}
+
+func (s *staticSAServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if request.GetConnection().GetPayload() != payload.IP || !IsStatic(request.GetConnection().GetMechanism()) {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	mechanism := request.GetConnection().GetMechanism()
+	ipsecMech.ToMechanism(mechanism).
+		SetDstIP(s.tunnelIP).
+		SetDstPort(espUDPPort)
+	if err := s.setKeys(ctx, mechanism); err != nil {
+		return nil, err
+	}
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = create(ctx, conn, s.vppConn, metadata.IsClient(s)); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := s.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *staticSAServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if IsStatic(conn.GetMechanism()) {
+		delInterface(ctx, s.vppConn, metadata.IsClient(s))
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// setKeys - sets the keys of the server outbound SA. New keys are generated each time the client rekeys
+func (s *staticSAServer) setKeys(ctx context.Context, mechanism *networkservice.Mechanism) error {
+	clientKeys, err := keysFromParameters(mechanism, SrcSPIKey, SrcKeyKey)
+	if err != nil {
+		return err
+	}
+	if clientKeys == nil {
+		return errors.New("ipsec mechanism has no client ESP keys")
+	}
+	keys, err := keysFromParameters(mechanism, DstSPIKey, DstKeyKey)
+	if err != nil {
+		return err
+	}
+	if state, ok := load(ctx, metadata.IsClient(s)); keys != nil && ok && state.inSPI == clientKeys.spi && state.outSPI == keys.spi {
+		return nil
+	}
+	if keys, err = generateKeys(); err != nil {
+		return err
+	}
+	keys.toParameters(mechanism, DstSPIKey, DstKeyKey)
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/govpp/binapi/ikev2_types"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+// trafficSelector - address range of the IKEv2 traffic selector
+type trafficSelector struct {
+	isLocal bool
+	start   net.IP
+	end     net.IP
+}
+
+func (ts *trafficSelector) String() string {
+	return fmt.Sprintf("%t:%s-%s", ts.isLocal, ts.start, ts.end)
+}
+
+func (ts *trafficSelector) toVpp() ikev2_types.Ikev2Ts {
+	return ikev2_types.Ikev2Ts{
+		IsLocal:   ts.isLocal,
+		StartPort: 0,
+		EndPort:   65535,
+		StartAddr: types.ToVppAddress(ts.start),
+		EndAddr:   types.ToVppAddress(ts.end),
+	}
+}
+
+// trafficSelectors - returns the traffic selectors for the connection: one per address and route prefix.
+// The NSC side (src addresses and dst routes reachable through the NSC) is local for the client, the NSE side
+// (dst addresses and src routes reachable through the NSE) is local for the server.
+func trafficSelectors(conn *networkservice.Connection, isClient bool) ([]*trafficSelector, error) {
+	ipContext := conn.GetContext().GetIpContext()
+
+	var rv []*trafficSelector
+	for _, side := range []struct {
+		isLocal bool
+		addrs   []string
+		routes  []*networkservice.Route
+	}{
+		{isLocal: isClient, addrs: ipContext.GetSrcIpAddrs(), routes: ipContext.GetDstRoutes()},
+		{isLocal: !isClient, addrs: ipContext.GetDstIpAddrs(), routes: ipContext.GetSrcRoutes()},
+	} {
+		for _, addr := range side.addrs {
+			ip, _, err := net.ParseCIDR(addr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse address with prefix %s", addr)
+			}
+			rv = append(rv, &trafficSelector{isLocal: side.isLocal, start: ip, end: ip})
+		}
+		for _, route := range side.routes {
+			_, ipNet, err := net.ParseCIDR(route.GetPrefix())
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse route prefix %s", route.GetPrefix())
+			}
+			rv = append(rv, &trafficSelector{isLocal: side.isLocal, start: ipNet.IP, end: lastIP(ipNet)})
+		}
+	}
+	return rv, nil
+}
+
+// sortTrafficSelectors - returns the selectors in the same order on both sides of the connection.
+// VPP keeps the last selector of each side in the IKEv2 profile for the child SA. The child SA protects all the
+// traffic routed into the tunnel interface, so the prefixes and families of the connection don't need more child SAs,
+// but both peers must end up with the same selectors.
+func sortTrafficSelectors(selectors []*trafficSelector) []*trafficSelector {
+	rv := append([]*trafficSelector(nil), selectors...)
+	sort.SliceStable(rv, func(i, j int) bool {
+		if c := bytes.Compare(rv[i].start.To16(), rv[j].start.To16()); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(rv[i].end.To16(), rv[j].end.To16()) < 0
+	})
+	return rv
+}
+
+// trafficSelectorsKey - returns the string uniquely identifying the set of the selectors
+func trafficSelectorsKey(selectors []*trafficSelector) string {
+	var keys []string
+	for _, ts := range selectors {
+		keys = append(keys, ts.String())
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+func lastIP(ipNet *net.IPNet) net.IP {
+	ip := ipNet.IP
+	if ip4 := ip.To4(); ip4 != nil && len(ipNet.Mask) == net.IPv4len {
+		ip = ip4
+	}
+	rv := make(net.IP, len(ip))
+	for i := range ip {
+		rv[i] = ip[i] | ^ipNet.Mask[i]
+	}
+	return rv
+}
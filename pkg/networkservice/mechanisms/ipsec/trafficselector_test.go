@@ -0,0 +1,141 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/govpp/binapi/ikev2"
+	"github.com/networkservicemesh/govpp/binapi/ikev2_types"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/stretchr/testify/require"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+type recordingConn struct {
+	api.Connection
+	selectors []ikev2_types.Ikev2Ts
+}
+
+func (c *recordingConn) Invoke(_ context.Context, req, _ api.Message) error {
+	if setTs, ok := req.(*ikev2.Ikev2ProfileSetTs); ok {
+		c.selectors = append(c.selectors, setTs.Ts)
+	}
+	return nil
+}
+
+type setTrafficSelectorServer struct {
+	vppConn api.Connection
+}
+
+func (s *setTrafficSelectorServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if err := setTrafficSelector(ctx, s.vppConn, "profile", request.GetConnection(), false); err != nil {
+		return nil, err
+	}
+	return request.GetConnection(), nil
+}
+
+func (s *setTrafficSelectorServer) Close(context.Context, *networkservice.Connection) (*empty.Empty, error) {
+	return new(empty.Empty), nil
+}
+
+func requestTrafficSelectors(t *testing.T, ipContext *networkservice.IPContext) ([]ikev2_types.Ikev2Ts, error) {
+	vppConn := &recordingConn{}
+	server := chain.NewNetworkServiceServer(metadata.NewServer(), &setTrafficSelectorServer{vppConn: vppConn})
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:      t.Name(),
+			Context: &networkservice.ConnectionContext{IpContext: ipContext},
+		},
+	})
+	return vppConn.selectors, err
+}
+
+func vppTs(isLocal bool, start, end string) ikev2_types.Ikev2Ts {
+	return ikev2_types.Ikev2Ts{
+		IsLocal:   isLocal,
+		StartPort: 0,
+		EndPort:   65535,
+		StartAddr: types.ToVppAddress(net.ParseIP(start)),
+		EndAddr:   types.ToVppAddress(net.ParseIP(end)),
+	}
+}
+
+func Test_SetTrafficSelector_Routes(t *testing.T) {
+	selectors, err := requestTrafficSelectors(t, &networkservice.IPContext{
+		SrcIpAddrs: []string{"172.16.0.1/32"},
+		DstIpAddrs: []string{"172.16.0.0/32"},
+		SrcRoutes:  []*networkservice.Route{{Prefix: "10.0.0.0/24"}},
+		DstRoutes:  []*networkservice.Route{{Prefix: "192.168.0.0/16"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []ikev2_types.Ikev2Ts{
+		vppTs(true, "10.0.0.0", "10.0.0.255"),
+		vppTs(true, "172.16.0.0", "172.16.0.0"),
+		vppTs(false, "172.16.0.1", "172.16.0.1"),
+		vppTs(false, "192.168.0.0", "192.168.255.255"),
+	}, selectors)
+}
+
+func Test_SetTrafficSelector_IPv6(t *testing.T) {
+	selectors, err := requestTrafficSelectors(t, &networkservice.IPContext{
+		SrcIpAddrs: []string{"fe80::1/128"},
+		DstIpAddrs: []string{"fe80::/128"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []ikev2_types.Ikev2Ts{
+		vppTs(true, "fe80::", "fe80::"),
+		vppTs(false, "fe80::1", "fe80::1"),
+	}, selectors)
+}
+
+func Test_SetTrafficSelector_DualStack(t *testing.T) {
+	selectors, err := requestTrafficSelectors(t, &networkservice.IPContext{
+		SrcIpAddrs: []string{"fe80::1/128", "172.16.0.1/32"},
+		DstIpAddrs: []string{"fe80::/128", "172.16.0.0/32"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []ikev2_types.Ikev2Ts{
+		vppTs(true, "172.16.0.0", "172.16.0.0"),
+		vppTs(false, "172.16.0.1", "172.16.0.1"),
+		vppTs(true, "fe80::", "fe80::"),
+		vppTs(false, "fe80::1", "fe80::1"),
+	}, selectors)
+}
+
+func Test_SetTrafficSelector_MultiplePrefixes(t *testing.T) {
+	selectors, err := requestTrafficSelectors(t, &networkservice.IPContext{
+		SrcIpAddrs: []string{"172.16.0.1/32"},
+		DstIpAddrs: []string{"172.16.0.0/32"},
+		SrcRoutes:  []*networkservice.Route{{Prefix: "10.0.2.0/24"}, {Prefix: "10.0.0.0/24"}, {Prefix: "2001:db8::/64"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []ikev2_types.Ikev2Ts{
+		vppTs(true, "10.0.0.0", "10.0.0.255"),
+		vppTs(true, "10.0.2.0", "10.0.2.255"),
+		vppTs(true, "172.16.0.0", "172.16.0.0"),
+		vppTs(false, "172.16.0.1", "172.16.0.1"),
+		vppTs(true, "2001:db8::", "2001:db8::ffff:ffff:ffff:ffff"),
+	}, selectors)
+}
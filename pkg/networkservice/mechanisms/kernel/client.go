@@ -20,20 +20,100 @@
 package kernel
 
 import (
-	"os"
+	"context"
 
+	"github.com/golang/protobuf/ptypes/empty"
 	"go.fd.io/govpp/api"
+	"google.golang.org/grpc"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kerneltap"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kernelvethpair"
 )
 
-// NewClient - returns a new Client chain element implementing the kernel mechanism with vpp
-func NewClient(vppConn api.Connection) networkservice.NetworkServiceClient {
-	if _, err := os.Stat(vnetFilename); err == nil {
-		return kerneltap.NewClient(vppConn)
+type kernelClient struct {
+	defaultBackend Backend
+	backends       map[Backend]networkservice.NetworkServiceClient
+}
+
+// NewClient - returns a new Client chain element implementing the kernel mechanism with vpp.
+// The Backend is selected by the BackendKey parameter of the connection mechanism if present and by WithBackend option otherwise.
+func NewClient(vppConn api.Connection, options ...Option) networkservice.NetworkServiceClient {
+	opts := &kernelOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+	afXDPOpts := append([]kernelvethpair.Option{kernelvethpair.WithAfXDP()}, opts.vethPairOpts...)
+
+	return &kernelClient{
+		defaultBackend: opts.defaultBackend(),
+		backends: map[Backend]networkservice.NetworkServiceClient{
+			TapBackend:      newBackendClient(kerneltap.NewClient(vppConn, opts.tapOpts...), TapBackend),
+			VethPairBackend: newBackendClient(kernelvethpair.NewClient(vppConn, opts.vethPairOpts...), VethPairBackend),
+			AfXDPBackend:    newBackendClient(kernelvethpair.NewClient(vppConn, afXDPOpts...), AfXDPBackend),
+		},
+	}
+}
+
+func (k *kernelClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	backend, ok := loadBackend(ctx, metadata.IsClient(k))
+	if !ok {
+		var err error
+		if backend, err = selectBackend(request.GetConnection().GetMechanism(), k.defaultBackend); err != nil {
+			return nil, err
+		}
+		storeBackend(ctx, metadata.IsClient(k), backend)
+	}
+
+	conn, err := k.backends[backend].Request(ctx, request, opts...)
+	if err != nil {
+		// the backend hasn't been used yet for the new connection
+		if !ok {
+			loadAndDeleteBackend(ctx, metadata.IsClient(k))
+		}
+		return nil, err
+	}
+	// the backend is kept only for the kernel connections
+	if !ok && kernel.ToMechanism(conn.GetMechanism()) == nil {
+		loadAndDeleteBackend(ctx, metadata.IsClient(k))
+	}
+	return conn, nil
+}
+
+func (k *kernelClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	backend, ok := loadAndDeleteBackend(ctx, metadata.IsClient(k))
+	if !ok {
+		return next.Client(ctx).Close(ctx, conn, opts...)
 	}
-	return kernelvethpair.NewClient(vppConn)
+	return k.backends[backend].Close(ctx, conn, opts...)
+}
+
+// backendClient - requests the kernel mechanism preferences added by the backend chain with the BackendKey parameter set
+type backendClient struct {
+	backend Backend
+}
+
+func newBackendClient(client networkservice.NetworkServiceClient, backend Backend) networkservice.NetworkServiceClient {
+	return chain.NewNetworkServiceClient(
+		client,
+		&backendClient{backend: backend},
+	)
+}
+
+func (b *backendClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	for _, mechanism := range request.GetMechanismPreferences() {
+		if mech := kernel.ToMechanism(mechanism); mech != nil && mech.GetParameters()[BackendKey] == "" {
+			setBackend(mechanism, b.backend)
+		}
+	}
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (b *backendClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
 }
@@ -0,0 +1,86 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package kernel
+
+import (
+	"context"
+	"os"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/pkg/errors"
+)
+
+func (opts *kernelOptions) defaultBackend() Backend {
+	if opts.backend != "" {
+		return opts.backend
+	}
+	if _, err := os.Stat(vnetFilename); err == nil {
+		return TapBackend
+	}
+	return VethPairBackend
+}
+
+// selectBackend - returns the backend requested by the mechanism parameters or the default one
+func selectBackend(mechanism *networkservice.Mechanism, defaultBackend Backend) (Backend, error) {
+	backend := defaultBackend
+	if mech := kernel.ToMechanism(mechanism); mech != nil {
+		if b := mech.GetParameters()[BackendKey]; b != "" {
+			backend = Backend(b)
+		}
+	}
+	switch backend {
+	case TapBackend, VethPairBackend, AfXDPBackend:
+		return backend, nil
+	}
+	return "", errors.Errorf("unsupported kernel backend: %s", backend)
+}
+
+func setBackend(mechanism *networkservice.Mechanism, backend Backend) {
+	if mechanism.GetParameters() == nil {
+		mechanism.Parameters = make(map[string]string)
+	}
+	mechanism.GetParameters()[BackendKey] = string(backend)
+}
+
+type backendKey struct{}
+
+func storeBackend(ctx context.Context, isClient bool, backend Backend) {
+	metadata.Map(ctx, isClient).Store(backendKey{}, backend)
+}
+
+func loadBackend(ctx context.Context, isClient bool) (Backend, bool) {
+	v, ok := metadata.Map(ctx, isClient).Load(backendKey{})
+	if !ok {
+		return "", false
+	}
+	backend, ok := v.(Backend)
+	return backend, ok
+}
+
+func loadAndDeleteBackend(ctx context.Context, isClient bool) (Backend, bool) {
+	v, ok := metadata.Map(ctx, isClient).LoadAndDelete(backendKey{})
+	if !ok {
+		return "", false
+	}
+	backend, ok := v.(Backend)
+	return backend, ok
+}
@@ -24,4 +24,19 @@ const (
 	// MECHANISM string
 	MECHANISM    = kernel.MECHANISM
 	vnetFilename = "/dev/vhost-net"
+
+	// BackendKey - mechanism parameter selecting the Backend used for the connection
+	BackendKey = "kernel_backend"
+)
+
+// Backend - the way the kernel interface is attached to vpp
+type Backend string
+
+const (
+	// TapBackend - kernel interface is a vpp tapv2 interface
+	TapBackend Backend = "tap"
+	// VethPairBackend - kernel interface is one end of a veth pair, the other end is attached to vpp with af_packet
+	VethPairBackend Backend = "veth"
+	// AfXDPBackend - kernel interface is one end of a veth pair, the other end is attached to vpp with af_xdp
+	AfXDPBackend Backend = "afxdp"
 )
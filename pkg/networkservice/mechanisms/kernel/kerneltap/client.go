@@ -37,12 +37,18 @@ import (
 
 type kernelTapClient struct {
 	vppConn api.Connection
+	tapOpts *kernelTapOptions
 }
 
 // NewClient - return a new Client chain element implementing the kernel mechanism with vpp using tapv2
-func NewClient(vppConn api.Connection) networkservice.NetworkServiceClient {
+func NewClient(vppConn api.Connection, options ...Option) networkservice.NetworkServiceClient {
+	opts := &kernelTapOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
 	return &kernelTapClient{
 		vppConn: vppConn,
+		tapOpts: opts,
 	}
 }
 
@@ -61,7 +67,7 @@ func (k *kernelTapClient) Request(ctx context.Context, request *networkservice.N
 		return nil, err
 	}
 
-	if err := create(ctx, conn, k.vppConn, metadata.IsClient(k)); err != nil {
+	if err := create(ctx, conn, k.vppConn, k.tapOpts, metadata.IsClient(k)); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
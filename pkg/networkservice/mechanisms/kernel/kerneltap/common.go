@@ -34,13 +34,16 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
 	kernellink "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nshandle"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/rxmode"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ethtool"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/mechutils"
 )
 
-func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
+func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, tapOpts *kernelTapOptions, isClient bool) error {
 	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		// Construct the netlink handle for the target namespace for this kernel interface
 		handle, err := kernellink.GetNetlinkHandle(mechanism.GetNetNSURL())
@@ -62,11 +65,18 @@ func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 			return err
 		}
 
+		params, err := tapOpts.parameters(conn.GetMechanism())
+		if err != nil {
+			return err
+		}
+
 		now := time.Now()
 		tapCreate := &tapv2.TapCreateV3{
 			ID:               ^uint32(0),
 			UseRandomMac:     true,
 			NumRxQueues:      1,
+			RxRingSz:         params.rxRingSize,
+			TxRingSz:         params.txRingSize,
 			HostIfNameSet:    true,
 			HostIfName:       mechanism.GetInterfaceName(),
 			HostNamespaceSet: true,
@@ -74,8 +84,23 @@ func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 			TapFlags:         tapv2.TAP_API_FLAG_TUN,
 		}
 
+		if params.queues != 0 {
+			tapCreate.NumRxQueues = params.queues
+			tapCreate.NumTxQueues = params.queues
+		}
+		if params.gso {
+			tapCreate.TapFlags |= tapv2.TAP_API_FLAG_GSO
+		}
+		if params.csumOffload {
+			tapCreate.TapFlags |= tapv2.TAP_API_FLAG_CSUM_OFFLOAD
+		}
+
 		if conn.GetPayload() == payload.Ethernet {
 			tapCreate.TapFlags ^= tapv2.TAP_API_FLAG_TUN
+			if params.persistentMAC {
+				tapCreate.HostMacAddrSet = true
+				tapCreate.HostMacAddr = macFromConnectionID(conn.GetId())
+			}
 		}
 
 		deadline, ok := ctx.Deadline()
@@ -94,22 +119,28 @@ func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 			WithField("HostIfName", tapCreate.HostIfName).
 			WithField("HostNamespace", tapCreate.HostNamespace).
 			WithField("TapFlags", tapCreate.TapFlags).
+			WithField("NumRxQueues", tapCreate.NumRxQueues).
+			WithField("RxRingSz", tapCreate.RxRingSz).
+			WithField("TxRingSz", tapCreate.TxRingSz).
 			WithField("duration", time.Since(now)).
 			WithField("vppapi", "TapCreateV3").Debug("completed")
 		ifindex.Store(ctx, isClient, rsp.SwIfIndex)
 
-		now = time.Now()
-		if _, err = interfaces.NewServiceClient(vppConn).SwInterfaceSetRxMode(ctx, &interfaces.SwInterfaceSetRxMode{
-			SwIfIndex: rsp.SwIfIndex,
-			Mode:      interface_types.RX_MODE_API_ADAPTIVE,
-		}); err != nil {
-			return errors.Wrap(err, "vppapi SwInterfaceSetRxMode returned error")
+		// The rx mode selected by the rxmode chain element overrides the adaptive one
+		if _, ok := rxmode.LoadMode(ctx, isClient); !ok {
+			now = time.Now()
+			if _, err = interfaces.NewServiceClient(vppConn).SwInterfaceSetRxMode(ctx, &interfaces.SwInterfaceSetRxMode{
+				SwIfIndex: rsp.SwIfIndex,
+				Mode:      interface_types.RX_MODE_API_ADAPTIVE,
+			}); err != nil {
+				return errors.Wrap(err, "vppapi SwInterfaceSetRxMode returned error")
+			}
+			log.FromContext(ctx).
+				WithField("swIfIndex", rsp.SwIfIndex).
+				WithField("mode", interface_types.RX_MODE_API_ADAPTIVE).
+				WithField("duration", time.Since(now)).
+				WithField("vppapi", "SwInterfaceSetRxMode").Debug("completed")
 		}
-		log.FromContext(ctx).
-			WithField("swIfIndex", rsp.SwIfIndex).
-			WithField("mode", interface_types.RX_MODE_API_ADAPTIVE).
-			WithField("duration", time.Since(now)).
-			WithField("vppapi", "SwInterfaceSetRxMode").Debug("completed")
 
 		now = time.Now()
 		l, err := handle.LinkByName(tapCreate.HostIfName)
@@ -134,6 +165,10 @@ func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 			WithField("duration", time.Since(now)).
 			WithField("netlink", "LinkSetAlias").Debug("completed")
 
+		if err = applyOffloadProfile(ctx, mechanism.GetNetNSURL(), l.Attrs().Name, tapOpts.offloadProfile); err != nil {
+			return err
+		}
+
 		// Up the link
 		now = time.Now()
 		err = handle.LinkSetUp(l)
@@ -148,6 +183,34 @@ func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 	return nil
 }
 
+func applyOffloadProfile(ctx context.Context, netNSURL, iface string, profile ethtool.Profile) error {
+	if len(profile) == 0 {
+		return nil
+	}
+	nsHandle, err := nshandle.FromURL(netNSURL)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = nsHandle.Close() }()
+
+	h, err := ethtool.NewHandleAt(nsHandle)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = h.Close() }()
+
+	now := time.Now()
+	if err := h.Apply(iface, profile); err != nil {
+		return err
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", iface).
+		WithField("profile", profile).
+		WithField("duration", time.Since(now)).
+		WithField("ethtool", "Apply").Debug("completed")
+	return nil
+}
+
 func del(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
 	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		swIfIndex, ok := ifindex.LoadAndDelete(ctx, isClient)
@@ -21,4 +21,20 @@ import "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kern
 const (
 	// MECHANISM string
 	MECHANISM = kernel.MECHANISM
+
+	// QueuesKey - mechanism parameter for the number of the tap rx and tx queues
+	QueuesKey = "tap_queues"
+	// RxRingSizeKey - mechanism parameter for the tap rx ring size
+	RxRingSizeKey = "tap_rx_ring_size"
+	// TxRingSizeKey - mechanism parameter for the tap tx ring size
+	TxRingSizeKey = "tap_tx_ring_size"
+	// GSOKey - mechanism parameter enabling ("true") or disabling ("false") the tap GSO
+	GSOKey = "tap_gso"
+	// CsumOffloadKey - mechanism parameter enabling ("true") or disabling ("false") the tap checksum offload
+	CsumOffloadKey = "tap_csum_offload"
+	// PersistentMACKey - mechanism parameter enabling ("true") or disabling ("false") the kernel interface MAC
+	// derived from the connection ID
+	PersistentMACKey = "tap_persistent_mac"
+
+	maxRingSize = 32768
 )
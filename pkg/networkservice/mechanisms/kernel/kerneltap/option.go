@@ -0,0 +1,79 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kerneltap
+
+import (
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ethtool"
+)
+
+type kernelTapOptions struct {
+	queues         uint16
+	rxRingSize     uint16
+	txRingSize     uint16
+	gso            bool
+	csumOffload    bool
+	persistentMAC  bool
+	offloadProfile ethtool.Profile
+}
+
+// Option is an option pattern for kerneltap chain elements.
+// The options set the defaults for the connections, the mechanism parameters override them per connection.
+type Option func(o *kernelTapOptions)
+
+// WithQueues - sets the number of the tap rx and tx queues
+func WithQueues(queues uint16) Option {
+	return func(o *kernelTapOptions) {
+		o.queues = queues
+	}
+}
+
+// WithRingSizes - sets the tap rx and tx ring sizes. Ring sizes must be powers of 2
+func WithRingSizes(rxRingSize, txRingSize uint16) Option {
+	return func(o *kernelTapOptions) {
+		o.rxRingSize = rxRingSize
+		o.txRingSize = txRingSize
+	}
+}
+
+// WithGSO - enables the tap generic segmentation offload
+func WithGSO() Option {
+	return func(o *kernelTapOptions) {
+		o.gso = true
+	}
+}
+
+// WithCsumOffload - enables the tap checksum offload
+func WithCsumOffload() Option {
+	return func(o *kernelTapOptions) {
+		o.csumOffload = true
+	}
+}
+
+// WithPersistentMAC - sets the kernel interface MAC derived from the connection ID instead of a random one,
+// so the interface keeps its MAC when it is recreated
+func WithPersistentMAC() Option {
+	return func(o *kernelTapOptions) {
+		o.persistentMAC = true
+	}
+}
+
+// WithOffloadProfile - sets the offload features of the kernel interface
+func WithOffloadProfile(profile ethtool.Profile) Option {
+	return func(o *kernelTapOptions) {
+		o.offloadProfile = profile
+	}
+}
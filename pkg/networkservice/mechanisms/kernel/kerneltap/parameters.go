@@ -0,0 +1,92 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kerneltap
+
+import (
+	"crypto/sha256"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// tapParameters - tap interface parameters for the connection. Zero values mean VPP defaults
+type tapParameters struct {
+	queues        uint16
+	rxRingSize    uint16
+	txRingSize    uint16
+	gso           bool
+	csumOffload   bool
+	persistentMAC bool
+}
+
+// parameters - returns the options overridden by the mechanism parameters
+func (o *kernelTapOptions) parameters(mechanism *networkservice.Mechanism) (*tapParameters, error) {
+	values := mechanism.GetParameters()
+	params := &tapParameters{
+		queues:        o.queues,
+		rxRingSize:    o.rxRingSize,
+		txRingSize:    o.txRingSize,
+		gso:           o.gso,
+		csumOffload:   o.csumOffload,
+		persistentMAC: o.persistentMAC,
+	}
+	for key, p := range map[string]*uint16{
+		QueuesKey:     &params.queues,
+		RxRingSizeKey: &params.rxRingSize,
+		TxRingSizeKey: &params.txRingSize,
+	} {
+		if values[key] == "" {
+			continue
+		}
+		v, err := strconv.ParseUint(values[key], 10, 16)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid kernel mechanism parameter %s", key)
+		}
+		*p = uint16(v)
+	}
+	for key, p := range map[string]*bool{
+		GSOKey:           &params.gso,
+		CsumOffloadKey:   &params.csumOffload,
+		PersistentMACKey: &params.persistentMAC,
+	} {
+		if values[key] == "" {
+			continue
+		}
+		v, err := strconv.ParseBool(values[key])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid kernel mechanism parameter %s", key)
+		}
+		*p = v
+	}
+	for _, ringSize := range []uint16{params.rxRingSize, params.txRingSize} {
+		if ringSize&(ringSize-1) != 0 || ringSize > maxRingSize {
+			return nil, errors.Errorf("tap ring size must be a power of 2 not greater than %d: %d", maxRingSize, ringSize)
+		}
+	}
+	return params, nil
+}
+
+// macFromConnectionID - returns a locally administered unicast MAC derived from the connection ID
+func macFromConnectionID(id string) [6]byte {
+	var mac [6]byte
+	sum := sha256.Sum256([]byte(id))
+	copy(mac[:], sum[:])
+	mac[0] = (mac[0] | 0x02) &^ 0x01
+	return mac
+}
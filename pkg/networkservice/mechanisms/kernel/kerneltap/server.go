@@ -35,12 +35,18 @@ import (
 
 type kernelTapServer struct {
 	vppConn api.Connection
+	tapOpts *kernelTapOptions
 }
 
 // NewServer - return a new Server chain element implementing the kernel mechanism with vpp using tapv2
-func NewServer(vppConn api.Connection) networkservice.NetworkServiceServer {
+func NewServer(vppConn api.Connection, options ...Option) networkservice.NetworkServiceServer {
+	opts := &kernelTapOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
 	return &kernelTapServer{
 		vppConn: vppConn,
+		tapOpts: opts,
 	}
 }
 
@@ -52,7 +58,7 @@ func (k *kernelTapServer) Request(ctx context.Context, request *networkservice.N
 		return nil, err
 	}
 
-	if err := create(ctx, conn, k.vppConn, metadata.IsClient(k)); err != nil {
+	if err := create(ctx, conn, k.vppConn, k.tapOpts, metadata.IsClient(k)); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -0,0 +1,95 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package afxdp
+
+import (
+	"context"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/govpp/binapi/af_xdp"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/peer"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/up"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
+		if _, ok := ifindex.Load(ctx, isClient); ok {
+			return nil
+		}
+		peerLink, ok := peer.Load(ctx, isClient)
+		if !ok {
+			return errors.New("peer link not found")
+		}
+		// veth has a single queue pair unless created with more
+		rxqNum := uint16(1)
+		if peerLink.Attrs().NumRxQueues > 1 {
+			rxqNum = uint16(peerLink.Attrs().NumRxQueues)
+		}
+		now := time.Now()
+		rsp, err := af_xdp.NewServiceClient(vppConn).AfXdpCreateV3(ctx, &af_xdp.AfXdpCreateV3{
+			HostIf: peerLink.Attrs().Name,
+			RxqNum: rxqNum,
+			Mode:   af_xdp.AF_XDP_API_MODE_AUTO,
+		})
+		if err != nil {
+			return errors.Wrap(err, "vppapi AfXdpCreateV3 returned error")
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", rsp.SwIfIndex).
+			WithField("HostIf", peerLink.Attrs().Name).
+			WithField("RxqNum", rxqNum).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "AfXdpCreateV3").Debug("completed")
+		ifindex.Store(ctx, isClient, rsp.SwIfIndex)
+
+		up.Store(ctx, isClient, true)
+	}
+	return nil
+}
+
+func del(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
+		swIfIndex, ok := ifindex.LoadAndDelete(ctx, isClient)
+		if !ok {
+			return nil
+		}
+		now := time.Now()
+		_, err := af_xdp.NewServiceClient(vppConn).AfXdpDelete(ctx, &af_xdp.AfXdpDelete{
+			SwIfIndex: swIfIndex,
+		})
+		if err != nil {
+			return errors.Wrap(err, "vppapi AfXdpDelete returned error")
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", swIfIndex).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "AfXdpDelete").Debug("completed")
+		return nil
+	}
+	return nil
+}
@@ -37,18 +37,36 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kernelvethpair/afpacket"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kernelvethpair/afxdp"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kernelvethpair/ipneighbor"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ethtool"
 )
 
-type kernelVethPairClient struct{}
+type kernelVethPairClient struct {
+	offloadProfile ethtool.Profile
+}
 
 // NewClient - return a new Client chain element implementing the kernel mechanism with vpp using a veth pair
-func NewClient(vppConn api.Connection) networkservice.NetworkServiceClient {
+func NewClient(vppConn api.Connection, options ...Option) networkservice.NetworkServiceClient {
+	opts := &kernelVethPairOptions{
+		offloadProfile: ethtool.DisableChkSumOffload,
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	vppLink := afpacket.NewClient(vppConn)
+	if opts.afXDP {
+		vppLink = afxdp.NewClient(vppConn)
+	}
+
 	return chain.NewNetworkServiceClient(
 		ipneighbor.NewClient(vppConn),
-		afpacket.NewClient(vppConn),
+		vppLink,
 		mtu.NewClient(),
-		&kernelVethPairClient{},
+		&kernelVethPairClient{
+			offloadProfile: opts.offloadProfile,
+		},
 	)
 }
 
@@ -67,7 +85,7 @@ func (k *kernelVethPairClient) Request(ctx context.Context, request *networkserv
 		return nil, err
 	}
 
-	if err := create(ctx, conn, metadata.IsClient(k)); err != nil {
+	if err := create(ctx, conn, k.offloadProfile, metadata.IsClient(k)); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -42,7 +42,7 @@ import (
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/mechutils"
 )
 
-func create(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+func create(ctx context.Context, conn *networkservice.Connection, offloadProfile ethtool.Profile, isClient bool) error {
 	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		// Construct the netlink handle for the target namespace for this kernel interface
 		handle, err := kernellink.GetNetlinkHandle(mechanism.GetNetNSURL())
@@ -100,8 +100,8 @@ func create(ctx context.Context, conn *networkservice.Connection, isClient bool)
 			WithField("duration", time.Since(now)).
 			WithField("netlink", "LinkAdd").Debug("completed")
 
-		err = ethtool.DisableVethChkSumOffload(veth)
-		if err != nil {
+		if err = applyOffloadProfile(ctx, offloadProfile, veth.Name, veth.PeerName); err != nil {
+			_ = netlink.LinkDel(l)
 			return err
 		}
 
@@ -244,3 +244,27 @@ func del(ctx context.Context, conn *networkservice.Connection, isClient bool) er
 	}
 	return nil
 }
+
+func applyOffloadProfile(ctx context.Context, profile ethtool.Profile, ifaces ...string) error {
+	if len(profile) == 0 {
+		return nil
+	}
+	h, err := ethtool.NewHandle()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = h.Close() }()
+
+	for _, iface := range ifaces {
+		now := time.Now()
+		if err := h.Apply(iface, profile); err != nil {
+			return err
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", iface).
+			WithField("profile", profile).
+			WithField("duration", time.Since(now)).
+			WithField("ethtool", "Apply").Debug("completed")
+	}
+	return nil
+}
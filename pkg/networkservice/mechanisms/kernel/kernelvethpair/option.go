@@ -0,0 +1,44 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kernelvethpair
+
+import (
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ethtool"
+)
+
+type kernelVethPairOptions struct {
+	afXDP          bool
+	offloadProfile ethtool.Profile
+}
+
+// Option is an option pattern for kernelvethpair chain elements
+type Option func(o *kernelVethPairOptions)
+
+// WithAfXDP - attaches the forwarder side of the veth pair to vpp with af_xdp instead of af_packet
+func WithAfXDP() Option {
+	return func(o *kernelVethPairOptions) {
+		o.afXDP = true
+	}
+}
+
+// WithOffloadProfile - sets the offload features of both ends of the veth pair.
+// By default the checksum offload is disabled: ethtool.DisableChkSumOffload
+func WithOffloadProfile(profile ethtool.Profile) Option {
+	return func(o *kernelVethPairOptions) {
+		o.offloadProfile = profile
+	}
+}
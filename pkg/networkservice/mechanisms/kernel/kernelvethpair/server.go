@@ -35,18 +35,36 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kernelvethpair/afpacket"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kernelvethpair/afxdp"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kernelvethpair/ipneighbor"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ethtool"
 )
 
-type kernelVethPairServer struct{}
+type kernelVethPairServer struct {
+	offloadProfile ethtool.Profile
+}
 
 // NewServer - return a new Server chain element implementing the kernel mechanism with vpp using a veth pair
-func NewServer(vppConn api.Connection) networkservice.NetworkServiceServer {
+func NewServer(vppConn api.Connection, options ...Option) networkservice.NetworkServiceServer {
+	opts := &kernelVethPairOptions{
+		offloadProfile: ethtool.DisableChkSumOffload,
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	vppLink := afpacket.NewServer(vppConn)
+	if opts.afXDP {
+		vppLink = afxdp.NewServer(vppConn)
+	}
+
 	return chain.NewNetworkServiceServer(
 		ipneighbor.NewServer(vppConn),
-		afpacket.NewServer(vppConn),
+		vppLink,
 		mtu.NewServer(),
-		&kernelVethPairServer{},
+		&kernelVethPairServer{
+			offloadProfile: opts.offloadProfile,
+		},
 	)
 }
 
@@ -58,7 +76,7 @@ func (k *kernelVethPairServer) Request(ctx context.Context, request *networkserv
 		return nil, err
 	}
 
-	if err := create(ctx, request.GetConnection(), false); err != nil {
+	if err := create(ctx, request.GetConnection(), k.offloadProfile, false); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -0,0 +1,53 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kernel
+
+import (
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kerneltap"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kernelvethpair"
+)
+
+type kernelOptions struct {
+	backend      Backend
+	tapOpts      []kerneltap.Option
+	vethPairOpts []kernelvethpair.Option
+}
+
+// Option is an option pattern for kernel chain elements
+type Option func(o *kernelOptions)
+
+// WithBackend - sets the Backend used for the connections not requesting one with the BackendKey parameter.
+// By default TapBackend is used if /dev/vhost-net is available and VethPairBackend otherwise.
+func WithBackend(backend Backend) Option {
+	return func(o *kernelOptions) {
+		o.backend = backend
+	}
+}
+
+// WithTapOptions - sets the options of TapBackend, e.g. the queues, the ring sizes and the offloads
+func WithTapOptions(opts ...kerneltap.Option) Option {
+	return func(o *kernelOptions) {
+		o.tapOpts = opts
+	}
+}
+
+// WithVethPairOptions - sets the options of VethPairBackend and AfXDPBackend, e.g. the offload profile
+func WithVethPairOptions(opts ...kernelvethpair.Option) Option {
+	return func(o *kernelOptions) {
+		o.vethPairOpts = opts
+	}
+}
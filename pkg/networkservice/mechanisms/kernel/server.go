@@ -20,20 +20,73 @@
 package kernel
 
 import (
-	"os"
+	"context"
 
+	"github.com/golang/protobuf/ptypes/empty"
 	"go.fd.io/govpp/api"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kerneltap"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kernelvethpair"
 )
 
-// NewServer return a NetworkServiceServer chain element that correctly handles the kernel Mechanism
-func NewServer(vppConn api.Connection) networkservice.NetworkServiceServer {
-	if _, err := os.Stat(vnetFilename); err == nil {
-		return kerneltap.NewServer(vppConn)
+type kernelServer struct {
+	defaultBackend Backend
+	backends       map[Backend]networkservice.NetworkServiceServer
+}
+
+// NewServer - returns a new Server chain element implementing the kernel mechanism with vpp.
+// The Backend is selected by the BackendKey mechanism parameter if present and by WithBackend option otherwise.
+func NewServer(vppConn api.Connection, options ...Option) networkservice.NetworkServiceServer {
+	opts := &kernelOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+	afXDPOpts := append([]kernelvethpair.Option{kernelvethpair.WithAfXDP()}, opts.vethPairOpts...)
+
+	return &kernelServer{
+		defaultBackend: opts.defaultBackend(),
+		backends: map[Backend]networkservice.NetworkServiceServer{
+			TapBackend:      kerneltap.NewServer(vppConn, opts.tapOpts...),
+			VethPairBackend: kernelvethpair.NewServer(vppConn, opts.vethPairOpts...),
+			AfXDPBackend:    kernelvethpair.NewServer(vppConn, afXDPOpts...),
+		},
+	}
+}
+
+func (k *kernelServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if kernel.ToMechanism(request.GetConnection().GetMechanism()) == nil {
+		return next.Server(ctx).Request(ctx, request)
+	}
+	backend, ok := loadBackend(ctx, metadata.IsClient(k))
+	if !ok {
+		var err error
+		if backend, err = selectBackend(request.GetConnection().GetMechanism(), k.defaultBackend); err != nil {
+			return nil, err
+		}
+		storeBackend(ctx, metadata.IsClient(k), backend)
+	}
+	setBackend(request.GetConnection().GetMechanism(), backend)
+
+	conn, err := k.backends[backend].Request(ctx, request)
+	if err != nil {
+		// the backend hasn't been used yet for the new connection
+		if !ok {
+			loadAndDeleteBackend(ctx, metadata.IsClient(k))
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (k *kernelServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	backend, ok := loadAndDeleteBackend(ctx, metadata.IsClient(k))
+	if !ok {
+		return next.Server(ctx).Close(ctx, conn)
 	}
-	return kernelvethpair.NewServer(vppConn)
+	return k.backends[backend].Close(ctx, conn)
 }
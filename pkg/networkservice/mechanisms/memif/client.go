@@ -47,6 +47,7 @@ type memifClient struct {
 	vppConn     api.Connection
 	changeNetNS bool
 	nsInfo      NetNSInfo
+	memifOpts   *memifOptions
 }
 
 // NewClient provides a NetworkServiceClient chain elements that support the memif Mechanism
@@ -62,6 +63,7 @@ func NewClient(chainCtx context.Context, vppConn api.Connection, options ...Opti
 			vppConn:     vppConn,
 			changeNetNS: opts.changeNetNS,
 			nsInfo:      newNetNSInfo(),
+			memifOpts:   opts,
 		},
 	)
 }
@@ -74,6 +76,13 @@ func (m *memifClient) Request(ctx context.Context, request *networkservice.Netwo
 		}
 		request.MechanismPreferences = append(request.MechanismPreferences, mechanism.Mechanism)
 	}
+	for _, p := range request.GetMechanismPreferences() {
+		if memif.ToMechanism(p) != nil {
+			if err := m.memifOpts.request(p); err != nil {
+				return nil, err
+			}
+		}
+	}
 
 	postponeCtxFunc := postpone.ContextWithValues(ctx)
 
@@ -87,6 +96,7 @@ func (m *memifClient) Request(ctx context.Context, request *networkservice.Netwo
 		if mechanism := memif.ToMechanism(conn.GetMechanism()); mechanism != nil && ok {
 			info.NSURL = mechanism.GetNetNSURL()
 			info.SocketFile = mechanism.GetSocketFilename()
+			info.Parameters = mechanism.GetParameters()
 			return conn, nil
 		}
 	}
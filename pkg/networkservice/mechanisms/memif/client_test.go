@@ -73,3 +73,32 @@ func Test_MemifClient_ShouldNotDuplicateMechanisms(t *testing.T) {
 
 	require.Len(t, req.MechanismPreferences, 1)
 }
+
+func Test_MemifClient_ShouldRequestParameters(t *testing.T) {
+	c := chain.NewNetworkServiceClient(metadata.NewClient(), memif.NewClient(context.Background(), nil,
+		memif.WithQueues(4, 2),
+		memif.WithRingSize(4096),
+		memif.WithSecret(),
+	))
+
+	req := &networkservice.NetworkServiceRequest{
+		MechanismPreferences: []*networkservice.Mechanism{},
+	}
+
+	_, err := c.Request(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, req.MechanismPreferences, 1)
+
+	params := req.MechanismPreferences[0].GetParameters()
+	require.Equal(t, "4", params[memif.RxQueuesKey])
+	require.Equal(t, "2", params[memif.TxQueuesKey])
+	require.Equal(t, "4096", params[memif.RingSizeKey])
+	require.Empty(t, params[memif.BufferSizeKey])
+	require.Len(t, params[memif.SecretKey], 23)
+
+	// The secret is kept on the refresh
+	secret := params[memif.SecretKey]
+	_, err = c.Request(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, secret, req.MechanismPreferences[0].GetParameters()[memif.SecretKey])
+}
@@ -127,16 +127,23 @@ func deleteMemifSocket(ctx context.Context, vppConn api.Connection, isClient boo
 	return nil
 }
 
-func createMemif(ctx context.Context, vppConn api.Connection, socketID uint32, mode memif.MemifMode, isClient bool) error {
+func createMemif(ctx context.Context, vppConn api.Connection, socketID uint32, mode memif.MemifMode, params *memifParameters, isClient bool) error {
 	role := memif.MEMIF_ROLE_API_MASTER
+	rxQueues, txQueues := params.txQueues, params.rxQueues
 	if isClient {
 		role = memif.MEMIF_ROLE_API_SLAVE
+		rxQueues, txQueues = params.rxQueues, params.txQueues
 	}
 	now := time.Now()
 	memifCreate := &memif.MemifCreate{
-		Role:     role,
-		SocketID: socketID,
-		Mode:     mode,
+		Role:       role,
+		SocketID:   socketID,
+		Mode:       mode,
+		RxQueues:   rxQueues,
+		TxQueues:   txQueues,
+		RingSize:   params.ringSize,
+		BufferSize: params.bufferSize,
+		Secret:     params.secret,
 	}
 	rsp, err := memif.NewServiceClient(vppConn).MemifCreate(ctx, memifCreate)
 	if err != nil {
@@ -146,6 +153,10 @@ func createMemif(ctx context.Context, vppConn api.Connection, socketID uint32, m
 		WithField("swIfIndex", rsp.SwIfIndex).
 		WithField("Role", memifCreate.Role).
 		WithField("SocketID", memifCreate.SocketID).
+		WithField("RxQueues", memifCreate.RxQueues).
+		WithField("TxQueues", memifCreate.TxQueues).
+		WithField("RingSize", memifCreate.RingSize).
+		WithField("BufferSize", memifCreate.BufferSize).
 		WithField("duration", time.Since(now)).
 		WithField("vppapi", "MemifCreate").Debug("completed")
 	ifindex.Store(ctx, isClient, rsp.SwIfIndex)
@@ -191,6 +202,10 @@ func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 				return nil
 			}
 		}
+		params, err := parametersFromMechanism(conn.GetMechanism())
+		if err != nil {
+			return err
+		}
 		_ = del(ctx, conn, vppConn, isClient)
 
 		mode := memif.MEMIF_MODE_API_IP
@@ -201,7 +216,7 @@ func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 		if err != nil {
 			return err
 		}
-		if err := createMemif(ctx, vppConn, socketID, mode, isClient); err != nil {
+		if err := createMemif(ctx, vppConn, socketID, mode, params, isClient); err != nil {
 			return err
 		}
 	}
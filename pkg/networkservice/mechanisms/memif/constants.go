@@ -23,4 +23,18 @@ import (
 const (
 	// MECHANISM string
 	MECHANISM = memif.MECHANISM
+
+	// RxQueuesKey - memif mechanism parameter key for the number of the client rx queues
+	RxQueuesKey = "rx_queues"
+	// TxQueuesKey - memif mechanism parameter key for the number of the client tx queues
+	TxQueuesKey = "tx_queues"
+	// RingSizeKey - memif mechanism parameter key for the number of entries of rx/tx rings
+	RingSizeKey = "ring_size"
+	// BufferSizeKey - memif mechanism parameter key for the size of the buffer allocated for each ring entry
+	BufferSizeKey = "buffer_size"
+	// SecretKey - memif mechanism parameter key for the secret the client must provide to connect
+	SecretKey = "secret"
+
+	// maxSecretLen - VPP limit of the memif secret length: the secret is kept in 24 bytes including the terminating NUL
+	maxSecretLen = 23
 )
@@ -53,9 +53,11 @@ func loadAndDelete(ctx context.Context) (value context.CancelFunc, ok bool) {
 
 type infoKey struct{}
 
-// Info contains client NSURL and SocketFile needed for direct memif
+// Info contains client NSURL and SocketFile needed for direct memif.
+// Parameters are the memif mechanism parameters negotiated with the NSE
 type Info struct {
 	NSURL, SocketFile string
+	Parameters        map[string]string
 }
 
 func storeInfo(ctx context.Context, val *Info) {
@@ -33,6 +33,7 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/rxmode"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 )
 
@@ -60,6 +61,11 @@ func (m *memifrxmodeServer) Request(ctx context.Context, request *networkservice
 		return conn, errors.Wrap(err, "failed to get memif mechanism")
 	}
 
+	// The rx mode selected by the rxmode chain element overrides the adaptive one
+	if _, ok := rxmode.LoadMode(ctx, metadata.IsClient(m)); ok {
+		return conn, nil
+	}
+
 	if ok := load(ctx, metadata.IsClient(m)); !ok {
 		swIfIndex, _ := ifindex.Load(ctx, metadata.IsClient(m))
 
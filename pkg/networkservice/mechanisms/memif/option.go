@@ -19,6 +19,11 @@ package memif
 type memifOptions struct {
 	directMemifEnabled bool
 	changeNetNS        bool
+	rxQueues           uint8
+	txQueues           uint8
+	ringSize           uint32
+	bufferSize         uint16
+	secret             bool
 }
 
 // Option is an option for the connect server
@@ -37,3 +42,36 @@ func WithChangeNetNS() Option {
 		o.changeNetNS = true
 	}
 }
+
+// WithQueues sets the number of the client rx and tx queues. Client requests the values,
+// server uses them as the defaults and the limits for the requested ones
+func WithQueues(rxQueues, txQueues uint8) Option {
+	return func(o *memifOptions) {
+		o.rxQueues = rxQueues
+		o.txQueues = txQueues
+	}
+}
+
+// WithRingSize sets the number of entries of rx/tx rings, must be a power of 2. Client requests the value,
+// server uses it as the default and the limit for the requested one
+func WithRingSize(ringSize uint32) Option {
+	return func(o *memifOptions) {
+		o.ringSize = ringSize
+	}
+}
+
+// WithBufferSize sets the size of the buffer allocated for each ring entry. Client requests the value,
+// server uses it as the default and the limit for the requested one
+func WithBufferSize(bufferSize uint16) Option {
+	return func(o *memifOptions) {
+		o.bufferSize = bufferSize
+	}
+}
+
+// WithSecret enables memif secrets: a random secret is generated per connection unless the client has provided one,
+// so that only the peer of the connection can attach to the memif socket
+func WithSecret() Option {
+	return func(o *memifOptions) {
+		o.secret = true
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memif
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// memifParameters - memif interface parameters negotiated in the mechanism parameters.
+// The queues are the client ones: the client rx queues are the server tx queues and vice versa.
+// Zero values mean VPP defaults
+type memifParameters struct {
+	rxQueues   uint8
+	txQueues   uint8
+	ringSize   uint32
+	bufferSize uint16
+	secret     string
+}
+
+// request - sets the parameters requested by the client. The parameters not set by the options are left as is
+func (o *memifOptions) request(mechanism *networkservice.Mechanism) error {
+	params, err := parametersFromMechanism(mechanism)
+	if err != nil {
+		return err
+	}
+	params.rxQueues = override(params.rxQueues, o.rxQueues)
+	params.txQueues = override(params.txQueues, o.txQueues)
+	params.ringSize = override(params.ringSize, o.ringSize)
+	params.bufferSize = override(params.bufferSize, o.bufferSize)
+	if o.secret && params.secret == "" {
+		if params.secret, err = generateSecret(); err != nil {
+			return err
+		}
+	}
+	params.toMechanism(mechanism)
+	return nil
+}
+
+// negotiate - limits the parameters requested by the client by the server ones and sets the server ones
+// for the parameters the client hasn't requested
+func (o *memifOptions) negotiate(mechanism *networkservice.Mechanism) error {
+	params, err := parametersFromMechanism(mechanism)
+	if err != nil {
+		return err
+	}
+	params.rxQueues = negotiateUint(params.rxQueues, o.rxQueues)
+	params.txQueues = negotiateUint(params.txQueues, o.txQueues)
+	params.ringSize = negotiateUint(params.ringSize, o.ringSize)
+	params.bufferSize = negotiateUint(params.bufferSize, o.bufferSize)
+	if o.secret && params.secret == "" {
+		if params.secret, err = generateSecret(); err != nil {
+			return err
+		}
+	}
+	params.toMechanism(mechanism)
+	return nil
+}
+
+func override[T uint8 | uint16 | uint32](value, option T) T {
+	if option != 0 {
+		return option
+	}
+	return value
+}
+
+func negotiateUint[T uint8 | uint16 | uint32](requested, limit T) T {
+	if requested == 0 || (limit != 0 && requested > limit) {
+		return limit
+	}
+	return requested
+}
+
+func parametersFromMechanism(mechanism *networkservice.Mechanism) (*memifParameters, error) {
+	values := mechanism.GetParameters()
+	params := &memifParameters{
+		secret: values[SecretKey],
+	}
+	if len(params.secret) > maxSecretLen {
+		return nil, errors.Errorf("memif secret is too long: %d > %d", len(params.secret), maxSecretLen)
+	}
+	for _, p := range []struct {
+		key     string
+		bitSize int
+		set     func(v uint64)
+	}{
+		{key: RxQueuesKey, bitSize: 8, set: func(v uint64) { params.rxQueues = uint8(v) }},
+		{key: TxQueuesKey, bitSize: 8, set: func(v uint64) { params.txQueues = uint8(v) }},
+		{key: RingSizeKey, bitSize: 32, set: func(v uint64) { params.ringSize = uint32(v) }},
+		{key: BufferSizeKey, bitSize: 16, set: func(v uint64) { params.bufferSize = uint16(v) }},
+	} {
+		if values[p.key] == "" {
+			continue
+		}
+		v, err := strconv.ParseUint(values[p.key], 10, p.bitSize)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid memif mechanism parameter %s", p.key)
+		}
+		p.set(v)
+	}
+	if params.ringSize&(params.ringSize-1) != 0 {
+		return nil, errors.Errorf("memif ring size must be a power of 2: %d", params.ringSize)
+	}
+	return params, nil
+}
+
+func (p *memifParameters) toMechanism(mechanism *networkservice.Mechanism) {
+	if mechanism.Parameters == nil {
+		mechanism.Parameters = make(map[string]string)
+	}
+	for key, value := range map[string]uint64{
+		RxQueuesKey:   uint64(p.rxQueues),
+		TxQueuesKey:   uint64(p.txQueues),
+		RingSizeKey:   uint64(p.ringSize),
+		BufferSizeKey: uint64(p.bufferSize),
+	} {
+		if value == 0 {
+			delete(mechanism.Parameters, key)
+			continue
+		}
+		mechanism.Parameters[key] = strconv.FormatUint(value, 10)
+	}
+	if p.secret == "" {
+		delete(mechanism.Parameters, SecretKey)
+		return
+	}
+	mechanism.Parameters[SecretKey] = p.secret
+}
+
+// copyParameters - replaces the negotiated parameters of the mechanism by the ones of the source mechanism parameters
+func copyParameters(mechanism *networkservice.Mechanism, source map[string]string) {
+	if mechanism.Parameters == nil {
+		mechanism.Parameters = make(map[string]string)
+	}
+	for _, key := range []string{RxQueuesKey, TxQueuesKey, RingSizeKey, BufferSizeKey, SecretKey} {
+		if value, ok := source[key]; ok {
+			mechanism.Parameters[key] = value
+			continue
+		}
+		delete(mechanism.Parameters, key)
+	}
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, (maxSecretLen+1)/2)
+	if _, err := rand.Read(buf); err != nil {
+		return "", errors.Wrap(err, "failed to generate memif secret")
+	}
+	return hex.EncodeToString(buf)[:maxSecretLen], nil
+}
@@ -43,6 +43,7 @@ type memifServer struct {
 	vppConn     api.Connection
 	changeNetNS bool
 	nsInfo      NetNSInfo
+	memifOpts   *memifOptions
 }
 
 // NewServer provides a NetworkServiceServer chain elements that support the memif Mechanism
@@ -64,6 +65,7 @@ func NewServer(chainCtx context.Context, vppConn api.Connection, options ...Opti
 			vppConn:     vppConn,
 			changeNetNS: opts.changeNetNS,
 			nsInfo:      newNetNSInfo(),
+			memifOpts:   opts,
 		},
 	)
 }
@@ -71,8 +73,13 @@ func NewServer(chainCtx context.Context, vppConn api.Connection, options ...Opti
 func (m *memifServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
 	postponeCtxFunc := postpone.ContextWithValues(ctx)
 
-	if mechanism := memif.ToMechanism(request.GetConnection().GetMechanism()); mechanism != nil && !m.changeNetNS {
-		mechanism.SetNetNSURL((&url.URL{Scheme: memif.FileScheme, Path: m.nsInfo.netNSPath}).String())
+	if mechanism := memif.ToMechanism(request.GetConnection().GetMechanism()); mechanism != nil {
+		if !m.changeNetNS {
+			mechanism.SetNetNSURL((&url.URL{Scheme: memif.FileScheme, Path: m.nsInfo.netNSPath}).String())
+		}
+		if err := m.memifOpts.negotiate(request.GetConnection().GetMechanism()); err != nil {
+			return nil, err
+		}
 	}
 
 	conn, err := next.Server(ctx).Request(ctx, request)
@@ -80,8 +87,11 @@ func (m *memifServer) Request(ctx context.Context, request *networkservice.Netwo
 		return nil, err
 	}
 
-	// In direct memif case do nothing.
+	// In direct memif case the client connects to the NSE socket, so it must use the NSE parameters.
 	if info, ok := memifproxy.LoadInfo(ctx); ok && info.SocketFile != "" {
+		if mechanism := conn.GetMechanism(); memif.ToMechanism(mechanism) != nil {
+			copyParameters(mechanism, info.Parameters)
+		}
 		return conn, nil
 	}
 
@@ -0,0 +1,92 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv6
+
+import (
+	"crypto/rand"
+	"net"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// sidAllocator - allocates random unique SIDs from the locator
+type sidAllocator struct {
+	locator *net.IPNet
+	mut     sync.Mutex
+	used    map[string]struct{}
+}
+
+func newSIDAllocator(locator *net.IPNet) (*sidAllocator, error) {
+	if locator == nil {
+		return nil, errors.New("srv6 locator is not set")
+	}
+	ones, bits := locator.Mask.Size()
+	if locator.IP.To4() != nil || bits != net.IPv6len*8 {
+		return nil, errors.Errorf("srv6 locator must be IPv6: %s", locator)
+	}
+	if bits-ones < minFunctionBits {
+		return nil, errors.Errorf("srv6 locator must leave at least %d bits for the SID functions: %s", minFunctionBits, locator)
+	}
+	return &sidAllocator{
+		locator: locator,
+		used:    make(map[string]struct{}),
+	}, nil
+}
+
+// allocate - allocates a SID, the first function bit separates the client and the server SIDs
+func (a *sidAllocator) allocate(isClient bool) (net.IP, error) {
+	ones, _ := a.locator.Mask.Size()
+	sideByte, sideBit := ones/8, byte(0x80>>(ones%8))
+
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	for i := 0; i < maxAllocateAttempts; i++ {
+		sid := make(net.IP, net.IPv6len)
+		if _, err := rand.Read(sid); err != nil {
+			return nil, errors.Wrap(err, "failed to generate srv6 SID")
+		}
+		for j := range sid {
+			sid[j] = a.locator.IP[j]&a.locator.Mask[j] | sid[j]&^a.locator.Mask[j]
+		}
+		sid[sideByte] &^= sideBit
+		if isClient {
+			sid[sideByte] |= sideBit
+		}
+		if sid.Equal(a.locator.IP.Mask(a.locator.Mask)) {
+			continue
+		}
+		if _, ok := a.used[sid.String()]; ok {
+			continue
+		}
+		a.used[sid.String()] = struct{}{}
+		return sid, nil
+	}
+	return nil, errors.Errorf("failed to allocate srv6 SID from %s", a.locator)
+}
+
+func (a *sidAllocator) release(sids ...net.IP) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	for _, sid := range sids {
+		if sid != nil {
+			delete(a.used, sid.String())
+		}
+	}
+}
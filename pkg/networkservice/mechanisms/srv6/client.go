@@ -0,0 +1,134 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv6
+
+import (
+	"context"
+	"net"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	srv6Mech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/srv6"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/srv6/mtu"
+)
+
+type srv6Client struct {
+	vppConn   api.Connection
+	tunnelIP  net.IP
+	allocator *sidAllocator
+}
+
+// NewClient - returns a new client for the srv6 remote mechanism
+func NewClient(vppConn api.Connection, tunnelIP net.IP, options ...Option) networkservice.NetworkServiceClient {
+	opts := &srv6Options{}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	allocator, err := newSIDAllocator(opts.locator)
+	if err != nil {
+		log.FromContext(context.Background()).Fatalf("srv6Client locator error: %v", err)
+	}
+
+	return chain.NewNetworkServiceClient(
+		&srv6Client{
+			vppConn:   vppConn,
+			tunnelIP:  tunnelIP,
+			allocator: allocator,
+		},
+		mtu.NewClient(vppConn, tunnelIP),
+	)
+}
+
+func (s *srv6Client) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	isClient := metadata.IsClient(s)
+	isIP := request.GetConnection().GetPayload() != payload.Ethernet
+
+	local, loaded, err := localSIDs(ctx, s.allocator, isIP, isClient)
+	if err != nil {
+		return nil, err
+	}
+
+	mechanism := &networkservice.Mechanism{
+		Cls:  cls.REMOTE,
+		Type: MECHANISM,
+		Parameters: map[string]string{
+			srv6Mech.SrcHostIP: s.tunnelIP.String(),
+		},
+	}
+	local.toMechanism(mechanism, srcKeys)
+	request.MechanismPreferences = append(request.MechanismPreferences, mechanism)
+	if m := request.GetConnection().GetMechanism(); m.GetType() == MECHANISM {
+		local.toMechanism(m, srcKeys)
+		m.GetParameters()[srv6Mech.SrcHostIP] = s.tunnelIP.String()
+	}
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		if !loaded {
+			releaseSIDs(ctx, s.allocator, isClient)
+		}
+		return nil, err
+	}
+
+	if conn.GetMechanism().GetType() != MECHANISM {
+		if err := del(ctx, s.vppConn, isClient); err != nil {
+			log.FromContext(ctx).WithField("srv6", "client").Errorf("error while deleting srv6 policies: %v", err.Error())
+		}
+		releaseSIDs(ctx, s.allocator, isClient)
+		return conn, nil
+	}
+
+	segments, err := toSegments(local, sidsFromMechanism(conn.GetMechanism(), dstKeys), isIP)
+	if err == nil {
+		err = update(ctx, s.vppConn, s.tunnelIP, segments, isClient)
+	}
+	if err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := s.Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *srv6Client) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := del(ctx, s.vppConn, metadata.IsClient(s)); err != nil {
+		log.FromContext(ctx).WithField("srv6", "client").Errorf("error while deleting srv6 policies: %v", err.Error())
+	}
+	releaseSIDs(ctx, s.allocator, metadata.IsClient(s))
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
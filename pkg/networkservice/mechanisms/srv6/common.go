@@ -0,0 +1,202 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv6
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/networkservicemesh/govpp/binapi/sr"
+	"github.com/networkservicemesh/govpp/binapi/sr_types"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	srv6Mech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/srv6"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+// sids - SIDs allocated by one side of the connection. sid4 and bsid4 are used for IP payload only
+type sids struct {
+	sid, bsid   net.IP
+	sid4, bsid4 net.IP
+}
+
+type paramKeys struct {
+	sid, bsid   string
+	sid4, bsid4 string
+}
+
+var (
+	srcKeys = paramKeys{sid: srv6Mech.SrcLocalSID, bsid: srv6Mech.SrcBSID, sid4: SrcLocalSID4, bsid4: SrcBSID4}
+	dstKeys = paramKeys{sid: srv6Mech.DstLocalSID, bsid: srv6Mech.DstBSID, sid4: DstLocalSID4, bsid4: DstBSID4}
+)
+
+// localSIDs - returns the SIDs of the connection allocating them if needed, loaded is true if they have been
+// allocated by the previous requests
+func localSIDs(ctx context.Context, allocator *sidAllocator, isIP, isClient bool) (s *sids, loaded bool, err error) {
+	if s, ok := loadSIDs(ctx, isClient); ok {
+		if (s.sid4 != nil) == isIP {
+			return s, true, nil
+		}
+		// The payload has changed, the SIDs are allocated again
+		releaseSIDs(ctx, allocator, isClient)
+	}
+	s = new(sids)
+	allocated := []*net.IP{&s.sid, &s.bsid}
+	if isIP {
+		allocated = append(allocated, &s.sid4, &s.bsid4)
+	}
+	for _, sid := range allocated {
+		if *sid, err = allocator.allocate(isClient); err != nil {
+			allocator.release(s.all()...)
+			return nil, false, err
+		}
+	}
+	storeSIDs(ctx, isClient, s)
+	return s, false, nil
+}
+
+func releaseSIDs(ctx context.Context, allocator *sidAllocator, isClient bool) {
+	if s, ok := loadAndDeleteSIDs(ctx, isClient); ok {
+		allocator.release(s.all()...)
+	}
+}
+
+func (s *sids) all() []net.IP {
+	return []net.IP{s.sid, s.bsid, s.sid4, s.bsid4}
+}
+
+func (s *sids) toMechanism(mechanism *networkservice.Mechanism, keys paramKeys) {
+	if mechanism.GetParameters() == nil {
+		mechanism.Parameters = make(map[string]string)
+	}
+	for key, sid := range map[string]net.IP{keys.sid: s.sid, keys.bsid: s.bsid, keys.sid4: s.sid4, keys.bsid4: s.bsid4} {
+		if sid == nil {
+			delete(mechanism.GetParameters(), key)
+			continue
+		}
+		mechanism.GetParameters()[key] = sid.String()
+	}
+}
+
+func sidsFromMechanism(mechanism *networkservice.Mechanism, keys paramKeys) *sids {
+	params := mechanism.GetParameters()
+	return &sids{
+		sid:   net.ParseIP(params[keys.sid]),
+		bsid:  net.ParseIP(params[keys.bsid]),
+		sid4:  net.ParseIP(params[keys.sid4]),
+		bsid4: net.ParseIP(params[keys.bsid4]),
+	}
+}
+
+func toSegments(local, peer *sids, isIP bool) ([]*Segment, error) {
+	segments := []*Segment{
+		{Behavior: sr_types.SR_BEHAVIOR_API_DX2, LocalSID: local.sid, BSID: local.bsid, PeerSID: peer.sid},
+	}
+	if isIP {
+		segments = []*Segment{
+			{Behavior: sr_types.SR_BEHAVIOR_API_DX6, LocalSID: local.sid, BSID: local.bsid, PeerSID: peer.sid},
+			{Behavior: sr_types.SR_BEHAVIOR_API_DX4, LocalSID: local.sid4, BSID: local.bsid4, PeerSID: peer.sid4},
+		}
+	}
+	for _, segment := range segments {
+		if segment.PeerSID.To16() == nil || segment.PeerSID.To4() != nil {
+			return nil, errors.Errorf("no valid srv6 peer SID provided for %s", segment.Behavior)
+		}
+	}
+	return segments, nil
+}
+
+// update - creates the SR policies for the segments replacing the previous ones if the peer has changed
+func update(ctx context.Context, vppConn api.Connection, tunnelIP net.IP, segments []*Segment, isClient bool) error {
+	if prev, ok := Load(ctx, isClient); ok {
+		if equal(prev, segments) {
+			return nil
+		}
+		if err := delPolicies(ctx, vppConn, prev); err != nil {
+			return err
+		}
+		loadAndDelete(ctx, isClient)
+	}
+	for i, segment := range segments {
+		now := time.Now()
+		policyAdd := &sr.SrPolicyAddV2{
+			BsidAddr: types.ToVppIP6Address(segment.BSID),
+			Weight:   ^uint32(0),
+			IsEncap:  true,
+			Sids: sr.Srv6SidList{
+				NumSids: 1,
+				Weight:  ^uint32(0),
+			},
+			EncapSrc: types.ToVppIP6Address(tunnelIP),
+		}
+		policyAdd.Sids.Sids[0] = types.ToVppIP6Address(segment.PeerSID)
+		if _, err := sr.NewServiceClient(vppConn).SrPolicyAddV2(ctx, policyAdd); err != nil {
+			_ = delPolicies(ctx, vppConn, segments[:i])
+			return errors.Wrap(err, "vppapi SrPolicyAddV2 returned error")
+		}
+		log.FromContext(ctx).
+			WithField("BsidAddr", segment.BSID).
+			WithField("Sid", segment.PeerSID).
+			WithField("EncapSrc", tunnelIP).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "SrPolicyAddV2").Debug("completed")
+	}
+	store(ctx, isClient, segments)
+	return nil
+}
+
+func del(ctx context.Context, vppConn api.Connection, isClient bool) error {
+	segments, ok := loadAndDelete(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	return delPolicies(ctx, vppConn, segments)
+}
+
+func delPolicies(ctx context.Context, vppConn api.Connection, segments []*Segment) error {
+	for _, segment := range segments {
+		now := time.Now()
+		if _, err := sr.NewServiceClient(vppConn).SrPolicyDel(ctx, &sr.SrPolicyDel{
+			BsidAddr: types.ToVppIP6Address(segment.BSID),
+		}); err != nil {
+			return errors.Wrap(err, "vppapi SrPolicyDel returned error")
+		}
+		log.FromContext(ctx).
+			WithField("BsidAddr", segment.BSID).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "SrPolicyDel").Debug("completed")
+	}
+	return nil
+}
+
+func equal(a, b []*Segment) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Behavior != b[i].Behavior || !a[i].LocalSID.Equal(b[i].LocalSID) ||
+			!a[i].BSID.Equal(b[i].BSID) || !a[i].PeerSID.Equal(b[i].PeerSID) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv6
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/srv6"
+)
+
+const (
+	// MECHANISM string
+	MECHANISM = srv6.MECHANISM
+
+	// SrcLocalSID4 - src End.DX4 LocalSID, IP payload only
+	SrcLocalSID4 = "src_localsid4"
+	// DstLocalSID4 - dst End.DX4 LocalSID, IP payload only
+	DstLocalSID4 = "dst_localsid4"
+	// SrcBSID4 - src BSID of the policy to DstLocalSID4, IP payload only
+	SrcBSID4 = "src_bsid4"
+	// DstBSID4 - dst BSID of the policy to SrcLocalSID4, IP payload only
+	DstBSID4 = "dst_bsid4"
+
+	// minFunctionBits - minimal number of the SID bits after the locator
+	minFunctionBits = 16
+	// maxAllocateAttempts - number of the random SIDs tried before giving up
+	maxAllocateAttempts = 32
+)
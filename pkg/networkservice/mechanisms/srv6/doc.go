@@ -0,0 +1,23 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package srv6 provides networkservice.NetworkService{Client,Server} chain elements for the srv6 remote mechanism.
+//
+// Each side allocates its SIDs from the locator: an End.DX2 SID for ethernet payload, End.DX6 and End.DX4 SIDs
+// for IP payload, and the binding SIDs of its SR policies encapsulating the traffic to the peer SIDs. The SIDs are
+// exchanged in the mechanism parameters. The local SIDs and the steering into the SR policies need the local
+// interface of the connection, so they are programmed by xconnect/srv6xconnect once both sides are created.
+package srv6
@@ -0,0 +1,87 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv6
+
+import (
+	"context"
+	"net"
+
+	"github.com/networkservicemesh/govpp/binapi/sr_types"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+// Segment - srv6 connection segment for one payload type
+type Segment struct {
+	// Behavior - behavior of LocalSID: End.DX2, End.DX6 or End.DX4
+	Behavior sr_types.SrBehavior
+	// LocalSID - SID decapsulating the traffic from the peer to the local interface
+	LocalSID net.IP
+	// BSID - binding SID of the SR policy encapsulating the traffic to PeerSID
+	BSID net.IP
+	// PeerSID - LocalSID of the peer
+	PeerSID net.IP
+}
+
+type segmentsKey struct{}
+
+type sidsKey struct{}
+
+func store(ctx context.Context, isClient bool, segments []*Segment) {
+	metadata.Map(ctx, isClient).Store(segmentsKey{}, segments)
+}
+
+// Load returns the srv6 segments of the connection stored in per Connection.Id metadata
+func Load(ctx context.Context, isClient bool) (value []*Segment, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(segmentsKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.([]*Segment)
+	return value, ok
+}
+
+func loadAndDelete(ctx context.Context, isClient bool) (value []*Segment, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(segmentsKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.([]*Segment)
+	return value, ok
+}
+
+func storeSIDs(ctx context.Context, isClient bool, s *sids) {
+	metadata.Map(ctx, isClient).Store(sidsKey{}, s)
+}
+
+func loadSIDs(ctx context.Context, isClient bool) (value *sids, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(sidsKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*sids)
+	return value, ok
+}
+
+func loadAndDeleteSIDs(ctx context.Context, isClient bool) (value *sids, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(sidsKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*sids)
+	return value, ok
+}
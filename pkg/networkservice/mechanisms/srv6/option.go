@@ -0,0 +1,36 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv6
+
+import (
+	"net"
+)
+
+type srv6Options struct {
+	locator *net.IPNet
+}
+
+// Option is an option pattern for srv6 server/client
+type Option func(o *srv6Options)
+
+// WithLocator sets the IPv6 locator the per connection SIDs are allocated from. The locator must be routed
+// to the forwarder by the underlay and must leave at least 16 bits for the SID functions
+func WithLocator(locator *net.IPNet) Option {
+	return func(o *srv6Options) {
+		o.locator = locator
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vhostuser
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type vhostUserClient struct {
+	vppConn       api.Connection
+	nsInfo        netNSInfo
+	nsInfoErr     error
+	vhostUserOpts *vhostUserOptions
+}
+
+// NewClient provides a NetworkServiceClient chain element that supports the vhostuser Mechanism
+func NewClient(vppConn api.Connection, options ...Option) networkservice.NetworkServiceClient {
+	opts := &vhostUserOptions{
+		mode:      ServerMode,
+		features:  DefaultFeatures,
+		socketDir: filepath.Join(os.TempDir(), "vhostuser"),
+	}
+	for _, o := range options {
+		o(opts)
+	}
+
+	nsInfo, err := newNetNSInfo()
+	return &vhostUserClient{
+		vppConn:       vppConn,
+		nsInfo:        nsInfo,
+		nsInfoErr:     err,
+		vhostUserOpts: opts,
+	}
+}
+
+func (v *vhostUserClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	if v.nsInfoErr != nil {
+		return nil, v.nsInfoErr
+	}
+	if !hasMechanismPreference(request) {
+		request.MechanismPreferences = append(request.MechanismPreferences, New(v.nsInfo.netNSPath))
+	}
+	for _, p := range request.GetMechanismPreferences() {
+		if mechanism := ToMechanism(p); mechanism != nil {
+			if err := v.vhostUserOpts.request(p); err != nil {
+				return nil, err
+			}
+			if mechanism.GetSocketFilename() == "" {
+				socketFilename, err := v.vhostUserOpts.socketFile(request.GetConnection())
+				if err != nil {
+					return nil, err
+				}
+				mechanism.SetSocketFilename(socketFilename)
+			}
+		}
+	}
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = create(ctx, conn, v.vppConn, v.vhostUserOpts, metadata.IsClient(v), v.nsInfo.netNS); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := v.Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (v *vhostUserClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	_ = del(ctx, conn, v.vppConn, metadata.IsClient(v))
+	defer v.vhostUserOpts.removeSocketDir(conn)
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func hasMechanismPreference(request *networkservice.NetworkServiceRequest) bool {
+	for _, p := range request.GetMechanismPreferences() {
+		if ToMechanism(p) != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vhostuser
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/networkservicemesh/govpp/binapi/vhost_user"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netns"
+	"go.fd.io/govpp/api"
+	"golang.org/x/sys/unix"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+type netNSInfo struct {
+	netNS     netns.NsHandle
+	netNSPath string
+}
+
+// newNetNSInfo should be called only once for single chain
+func newNetNSInfo() (netNSInfo, error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	fd, err := unix.Open("/proc/thread-self/ns/net", unix.O_RDONLY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return netNSInfo{}, errors.Wrap(err, "failed to open '/proc/thread-self/ns/net'")
+	}
+	netNSPath := fmt.Sprintf("/proc/%d/fd/%d", os.Getpid(), fd)
+
+	netNS, err := netns.GetFromPath(netNSPath)
+	if err != nil {
+		_ = unix.Close(fd)
+		return netNSInfo{}, errors.Wrap(err, "failed to get current net NS")
+	}
+
+	return netNSInfo{
+		netNSPath: netNSPath,
+		netNS:     netNS,
+	}, nil
+}
+
+func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, opts *vhostUserOptions, isClient bool, netNS netns.NsHandle) error {
+	if mechanism := ToMechanism(conn.GetMechanism()); mechanism != nil {
+		if !isClient && mechanism.GetSocketFilename() == "" {
+			socketFilename, err := opts.socketFile(conn)
+			if err != nil {
+				return err
+			}
+			mechanism.SetSocketFilename(socketFilename)
+		}
+		socketFilename, err := getVppSocketFilename(mechanism, netNS)
+		if err != nil {
+			return err
+		}
+		// This connection has already been created
+		if _, ok := ifindex.Load(ctx, isClient); ok {
+			if prev, ok := loadSocketFilename(ctx, isClient); ok && prev == socketFilename {
+				return nil
+			}
+		}
+		params, err := parametersFromMechanism(conn.GetMechanism())
+		if err != nil {
+			return err
+		}
+		_ = del(ctx, conn, vppConn, isClient)
+
+		now := time.Now()
+		vhostUserCreate := &vhost_user.CreateVhostUserIfV2{
+			IsServer:            params.mode != ClientMode,
+			SockFilename:        socketFilename,
+			DisableMrgRxbuf:     params.features&FeatureMrgRxbuf == 0,
+			DisableIndirectDesc: params.features&FeatureIndirectDesc == 0,
+			EnableGso:           params.features&FeatureGSO != 0,
+			EnablePacked:        params.features&FeaturePacked != 0,
+			EnableEventIdx:      params.features&FeatureEventIdx != 0,
+			Tag:                 conn.GetId(),
+		}
+		rsp, err := vhost_user.NewServiceClient(vppConn).CreateVhostUserIfV2(ctx, vhostUserCreate)
+		if err != nil {
+			return errors.Wrap(err, "vppapi CreateVhostUserIfV2 returned error")
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", rsp.SwIfIndex).
+			WithField("SockFilename", vhostUserCreate.SockFilename).
+			WithField("IsServer", vhostUserCreate.IsServer).
+			WithField("Features", params.features).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "CreateVhostUserIfV2").Debug("completed")
+		ifindex.Store(ctx, isClient, rsp.SwIfIndex)
+		storeSocketFilename(ctx, isClient, socketFilename)
+	}
+	return nil
+}
+
+func del(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
+	if mechanism := ToMechanism(conn.GetMechanism()); mechanism != nil {
+		loadAndDeleteSocketFilename(ctx, isClient)
+		swIfIndex, ok := ifindex.LoadAndDelete(ctx, isClient)
+		if !ok {
+			return nil
+		}
+		now := time.Now()
+		if _, err := vhost_user.NewServiceClient(vppConn).DeleteVhostUserIf(ctx, &vhost_user.DeleteVhostUserIf{
+			SwIfIndex: swIfIndex,
+		}); err != nil {
+			return errors.Wrap(err, "vppapi DeleteVhostUserIf returned error")
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", swIfIndex).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "DeleteVhostUserIf").Debug("completed")
+	}
+	return nil
+}
+
+// socketFile - returns the socket file of the connection creating its dir if it is a filesystem socket
+func (o *vhostUserOptions) socketFile(conn *networkservice.Connection) (string, error) {
+	socketFilename := filepath.Join(o.socketDir, conn.GetId(), "vhostuser.socket")
+	if o.abstractSockets {
+		return "@" + socketFilename, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(socketFilename), 0o750); err != nil {
+		return "", errors.Wrapf(err, "failed to create vhost-user socket dir for %s", socketFilename)
+	}
+	return socketFilename, nil
+}
+
+// removeSocketDir - removes the dir of the connection filesystem socket file if it has been created by socketFile
+func (o *vhostUserOptions) removeSocketDir(conn *networkservice.Connection) {
+	socketFilename := filepath.Join(o.socketDir, conn.GetId(), "vhostuser.socket")
+	if ToMechanism(conn.GetMechanism()).GetSocketFilename() != socketFilename {
+		return
+	}
+	_ = os.RemoveAll(filepath.Dir(socketFilename))
+}
+
+func getVppSocketFilename(mechanism *Mechanism, netNS netns.NsHandle) (string, error) {
+	if mechanism.GetSocketFilename() == "" {
+		return "", errors.New("vhost-user socket filename is not set")
+	}
+	u, err := url.Parse(mechanism.GetNetNSURL())
+	if err != nil {
+		return "", errors.Wrapf(err, "not a valid url %s", mechanism.GetNetNSURL())
+	}
+	if u.Scheme != FileScheme {
+		return "", errors.Errorf("socket file url must have scheme %s, actual %s", FileScheme, u.Scheme)
+	}
+
+	targetNetNS, err := netns.GetFromPath(u.Path)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get network namespace handle for %s", u.Path)
+	}
+	defer func() { _ = targetNetNS.Close() }()
+
+	// Filesystem sockets don't belong to any net NS
+	if !strings.HasPrefix(mechanism.GetSocketFilename(), "@") {
+		return mechanism.GetSocketFilename(), nil
+	}
+
+	// VPP uses "abstract:" notation to create an abstract socket. But once created on Unix, it has "@" prefix.
+	// According to the VPP API we have to replace "@" with "abstract:"
+	vppSocketFilename := strings.ReplaceAll(mechanism.GetSocketFilename(), "@", "abstract:")
+	if !targetNetNS.Equal(netNS) {
+		return fmt.Sprintf("%s,netns_name=%s", vppSocketFilename, u.Path), nil
+	}
+	return vppSocketFilename, nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vhostuser
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+)
+
+const (
+	// MECHANISM string
+	MECHANISM = "VHOSTUSER"
+
+	// Mechanism parameters
+
+	// SocketFilename - path of the vhost-user socketfile in the filesystem of the peer,
+	// names starting with "@" are abstract sockets in the net NS of the peer
+	SocketFilename = "socketfile"
+
+	// NetNSURL - NetNS URL of the socketfile, it can be either:
+	// * file:///proc/${pid}/ns/net - ${pid} process net NS
+	// * inode://${dev}/${ino} - while transferring file between processes using grpcfd
+	NetNSURL = common.InodeURL
+
+	// ModeKey - mechanism parameter key for the Mode of the vpp side of the socket
+	ModeKey = "vhost_user_mode"
+	// FeaturesKey - mechanism parameter key for the Features of the vpp side of the interface
+	FeaturesKey = "features"
+
+	// FileScheme - expected scheme of the NetNSURL
+	FileScheme = "file"
+)
+
+// Mode - vhost-user socket mode of the vpp side of the connection
+type Mode string
+
+const (
+	// ServerMode - vpp creates and listens on the socket, the peer connects to it
+	ServerMode Mode = "server"
+	// ClientMode - the peer creates and listens on the socket, vpp connects to it
+	ClientMode Mode = "client"
+)
+
+// Features - vhost-user interface feature mask
+type Features uint32
+
+const (
+	// FeatureMrgRxbuf - mergeable rx buffers
+	FeatureMrgRxbuf Features = 1 << iota
+	// FeatureIndirectDesc - indirect descriptors
+	FeatureIndirectDesc
+	// FeatureGSO - generic segmentation offload
+	FeatureGSO
+	// FeaturePacked - packed rings
+	FeaturePacked
+	// FeatureEventIdx - event index
+	FeatureEventIdx
+
+	// DefaultFeatures - vpp default vhost-user features
+	DefaultFeatures = FeatureMrgRxbuf | FeatureIndirectDesc
+)
@@ -0,0 +1,22 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vhostuser provides chain elements for the vhost-user mechanism using vpp, e.g. for the VM based NSCs.
+// The vhost-user socket is a filesystem socket in the socket dir shared by the peer and vpp (e.g. a host dir
+// mounted to both of them), abstract sockets in the net NS of the peer as memif ones are used on demand.
+// The peer (e.g. QEMU) is expected to use the mechanism parameters to attach to the socket.
+// vpp doesn't limit the number of the queue pairs: it serves as many of them as the peer sets up.
+package vhostuser
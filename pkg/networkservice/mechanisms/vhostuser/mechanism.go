@@ -0,0 +1,87 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vhostuser
+
+import (
+	"net/url"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+)
+
+// Mechanism provides helper methods for mechanisms of type vhostuser
+type Mechanism struct {
+	*networkservice.Mechanism
+}
+
+// New returns *networkservice.Mechanism of type vhostuser using the given netNSPath
+func New(netNSPath string) *networkservice.Mechanism {
+	return &networkservice.Mechanism{
+		Cls:  cls.LOCAL,
+		Type: MECHANISM,
+		Parameters: map[string]string{
+			NetNSURL: (&url.URL{Scheme: FileScheme, Path: netNSPath}).String(),
+		},
+	}
+}
+
+// ToMechanism turns a networkservice.Mechanism into a version with helper methods for vhostuser
+// If Mechanism m is *not* of type vhostuser.MECHANISM, it returns nil
+func ToMechanism(m *networkservice.Mechanism) *Mechanism {
+	if m.GetType() == MECHANISM {
+		return &Mechanism{
+			m,
+		}
+	}
+	return nil
+}
+
+// GetParameters returns the map of all parameters to the mechanism
+func (m *Mechanism) GetParameters() map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	if m.Parameters == nil {
+		m.Parameters = map[string]string{}
+	}
+	return m.Parameters
+}
+
+// GetSocketFilename returns vhostuser mechanism socket filename
+func (m *Mechanism) GetSocketFilename() string {
+	return m.GetParameters()[SocketFilename]
+}
+
+// SetSocketFilename sets vhostuser mechanism socket filename
+func (m *Mechanism) SetSocketFilename(filename string) {
+	m.GetParameters()[SocketFilename] = filename
+}
+
+// GetNetNSURL returns the NetNS URL of the socket
+func (m *Mechanism) GetNetNSURL() string {
+	return m.GetParameters()[NetNSURL]
+}
+
+// SetNetNSURL sets the NetNS URL of the socket - file:///proc/${pid}/ns/net
+func (m *Mechanism) SetNetNSURL(urlString string) {
+	m.GetParameters()[NetNSURL] = urlString
+}
+
+// GetMode returns the Mode of the vpp side of the socket
+func (m *Mechanism) GetMode() Mode {
+	return Mode(m.GetParameters()[ModeKey])
+}
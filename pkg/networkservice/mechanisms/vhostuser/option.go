@@ -0,0 +1,59 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vhostuser
+
+type vhostUserOptions struct {
+	mode            Mode
+	features        Features
+	socketDir       string
+	abstractSockets bool
+}
+
+// Option is an option pattern for vhostuser chain elements
+type Option func(o *vhostUserOptions)
+
+// WithMode sets the socket Mode of vpp. Client requests the value, server uses it as the default for the connections
+// not requesting one. ServerMode by default
+func WithMode(mode Mode) Option {
+	return func(o *vhostUserOptions) {
+		o.mode = mode
+	}
+}
+
+// WithFeatures sets the interface features. Client requests the value,
+// server uses it as the default and the mask for the requested one. DefaultFeatures by default
+func WithFeatures(features Features) Option {
+	return func(o *vhostUserOptions) {
+		o.features = features
+	}
+}
+
+// WithSocketDir sets the dir the sockets are created in: ${socketDir}/${connection id}/vhostuser.socket.
+// It must be the same path in the filesystems of the peer and vpp, e.g. a shared host dir.
+// os.TempDir()/vhostuser by default
+func WithSocketDir(socketDir string) Option {
+	return func(o *vhostUserOptions) {
+		o.socketDir = socketDir
+	}
+}
+
+// WithAbstractSockets sets to use the abstract sockets in the net NS of the peer instead of the filesystem ones
+func WithAbstractSockets() Option {
+	return func(o *vhostUserOptions) {
+		o.abstractSockets = true
+	}
+}
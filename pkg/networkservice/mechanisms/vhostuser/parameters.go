@@ -0,0 +1,100 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vhostuser
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// vhostUserParameters - vhost-user interface parameters negotiated in the mechanism parameters.
+// Zero values mean not set
+type vhostUserParameters struct {
+	mode     Mode
+	features Features
+}
+
+// request - sets the parameters requested by the client. The parameters not set by the options are left as is
+func (o *vhostUserOptions) request(mechanism *networkservice.Mechanism) error {
+	params, err := parametersFromMechanism(mechanism)
+	if err != nil {
+		return err
+	}
+	if o.mode != "" {
+		params.mode = o.mode
+	}
+	if o.features != 0 {
+		params.features = o.features
+	}
+	params.toMechanism(mechanism)
+	return nil
+}
+
+// negotiate - limits the parameters requested by the client by the server ones and sets the server ones
+// for the parameters the client hasn't requested
+func (o *vhostUserOptions) negotiate(mechanism *networkservice.Mechanism) error {
+	params, err := parametersFromMechanism(mechanism)
+	if err != nil {
+		return err
+	}
+	if params.mode == "" {
+		params.mode = o.mode
+	}
+	if params.features == 0 {
+		params.features = o.features
+	}
+	params.features &= o.features
+	params.toMechanism(mechanism)
+	return nil
+}
+
+func parametersFromMechanism(mechanism *networkservice.Mechanism) (*vhostUserParameters, error) {
+	values := mechanism.GetParameters()
+	params := &vhostUserParameters{
+		mode: Mode(values[ModeKey]),
+	}
+	switch params.mode {
+	case "", ServerMode, ClientMode:
+	default:
+		return nil, errors.Errorf("invalid vhost-user mode: %s", params.mode)
+	}
+	if v := values[FeaturesKey]; v != "" {
+		features, err := strconv.ParseUint(v, 0, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid vhost-user mechanism parameter %s", FeaturesKey)
+		}
+		params.features = Features(features)
+	}
+	return params, nil
+}
+
+func (p *vhostUserParameters) toMechanism(mechanism *networkservice.Mechanism) {
+	if mechanism.Parameters == nil {
+		mechanism.Parameters = make(map[string]string)
+	}
+	delete(mechanism.Parameters, ModeKey)
+	if p.mode != "" {
+		mechanism.Parameters[ModeKey] = string(p.mode)
+	}
+	delete(mechanism.Parameters, FeaturesKey)
+	if p.features != 0 {
+		mechanism.Parameters[FeaturesKey] = "0x" + strconv.FormatUint(uint64(p.features), 16)
+	}
+}
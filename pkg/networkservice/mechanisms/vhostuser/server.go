@@ -0,0 +1,104 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vhostuser
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type vhostUserServer struct {
+	vppConn       api.Connection
+	nsInfo        netNSInfo
+	nsInfoErr     error
+	vhostUserOpts *vhostUserOptions
+}
+
+// NewServer provides a NetworkServiceServer chain element that supports the vhostuser Mechanism
+func NewServer(vppConn api.Connection, options ...Option) networkservice.NetworkServiceServer {
+	opts := &vhostUserOptions{
+		mode:      ServerMode,
+		features:  DefaultFeatures,
+		socketDir: filepath.Join(os.TempDir(), "vhostuser"),
+	}
+	for _, o := range options {
+		o(opts)
+	}
+
+	nsInfo, err := newNetNSInfo()
+	return &vhostUserServer{
+		vppConn:       vppConn,
+		nsInfo:        nsInfo,
+		nsInfoErr:     err,
+		vhostUserOpts: opts,
+	}
+}
+
+func (v *vhostUserServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if v.nsInfoErr != nil {
+		return nil, v.nsInfoErr
+	}
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	if mechanism := ToMechanism(request.GetConnection().GetMechanism()); mechanism != nil {
+		if mechanism.GetNetNSURL() == "" {
+			mechanism.SetNetNSURL((&url.URL{Scheme: FileScheme, Path: v.nsInfo.netNSPath}).String())
+		}
+		if err := v.vhostUserOpts.negotiate(request.GetConnection().GetMechanism()); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = create(ctx, conn, v.vppConn, v.vhostUserOpts, metadata.IsClient(v), v.nsInfo.netNS); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := v.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (v *vhostUserServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	_ = del(ctx, conn, v.vppConn, metadata.IsClient(v))
+	v.vhostUserOpts.removeSocketDir(conn)
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vlan
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/networkservicemesh/govpp/binapi/bond"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/domain2device"
+)
+
+// BondMode - the way the bond uses its members
+type BondMode uint8
+
+const (
+	// BondLACP - the members are aggregated with 802.3ad LACP
+	BondLACP BondMode = iota
+	// BondActiveBackup - only one member is active, the others take over on its failure
+	BondActiveBackup
+)
+
+// Bond - VPP bond aggregating several host devices to the uplink of a vlan domain
+type Bond struct {
+	// ID - the bond is named BondEthernet<ID> in VPP
+	ID uint32
+	// Mode - LACP or active-backup
+	Mode BondMode
+	// Members - host devices enslaved to the bond. They are attached to VPP by the host attach mode if missing
+	Members []string
+}
+
+func (b *Bond) name() string {
+	return fmt.Sprintf("BondEthernet%d", b.ID)
+}
+
+// bondProvider - returns the bonds for the vlan domains aggregating several host devices
+type bondProvider struct {
+	domain2device.Provider
+	bonds map[string]string
+}
+
+func (p *bondProvider) Device(domain string) (device string, ok bool) {
+	if device, ok := p.bonds[domain]; ok {
+		return device, ok
+	}
+	return p.Provider.Device(domain)
+}
+
+// createBond - creates the bond and enslaves its members, attaching the members missing in VPP
+func (h *hostInterfaces) createBond(ctx context.Context, vppConn api.Connection, b *Bond, hostIf *hostInterface) (interface_types.InterfaceIndex, error) {
+	for _, name := range b.Members {
+		member := &hostInterface{name: name}
+		swIfIndex, ok, err := findHostInterface(ctx, vppConn, name)
+		if err == nil && !ok {
+			if h.mode == AttachNone {
+				err = errors.Errorf("bond member %s is not attached to VPP", name)
+			} else if swIfIndex, err = h.attach(ctx, vppConn, name); err == nil {
+				member.attached = true
+			}
+		}
+		if err != nil {
+			return 0, multierror.Append(err, h.deleteBond(ctx, vppConn, hostIf))
+		}
+		member.swIfIndex = swIfIndex
+		hostIf.members = append(hostIf.members, member)
+	}
+
+	mode, lb := bond.BOND_API_MODE_LACP, bond.BOND_API_LB_ALGO_L34
+	if b.Mode == BondActiveBackup {
+		mode, lb = bond.BOND_API_MODE_ACTIVE_BACKUP, bond.BOND_API_LB_ALGO_AB
+	}
+	now := time.Now()
+	rsp, err := bond.NewServiceClient(vppConn).BondCreate2(ctx, &bond.BondCreate2{
+		Mode: mode,
+		Lb:   lb,
+		ID:   b.ID,
+	})
+	if err != nil {
+		return 0, multierror.Append(errors.Wrap(err, "vppapi BondCreate2 returned error"), h.deleteBond(ctx, vppConn, hostIf))
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", rsp.SwIfIndex).
+		WithField("ID", b.ID).
+		WithField("Mode", mode).
+		WithField("Lb", lb).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "BondCreate2").Debug("completed")
+	hostIf.swIfIndex = rsp.SwIfIndex
+
+	for _, member := range hostIf.members {
+		if err := addMember(ctx, vppConn, rsp.SwIfIndex, member.swIfIndex); err != nil {
+			return 0, multierror.Append(err, h.deleteBond(ctx, vppConn, hostIf))
+		}
+	}
+	if err := setAdminUp(ctx, vppConn, rsp.SwIfIndex); err != nil {
+		return 0, multierror.Append(err, h.deleteBond(ctx, vppConn, hostIf))
+	}
+	return rsp.SwIfIndex, nil
+}
+
+func addMember(ctx context.Context, vppConn api.Connection, bondSwIfIndex, swIfIndex interface_types.InterfaceIndex) error {
+	if err := setAdminUp(ctx, vppConn, swIfIndex); err != nil {
+		return err
+	}
+	now := time.Now()
+	if _, err := bond.NewServiceClient(vppConn).BondAddMember(ctx, &bond.BondAddMember{
+		SwIfIndex:     swIfIndex,
+		BondSwIfIndex: bondSwIfIndex,
+	}); err != nil {
+		return errors.Wrap(err, "vppapi BondAddMember returned error")
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("BondSwIfIndex", bondSwIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "BondAddMember").Debug("completed")
+	return nil
+}
+
+// deleteBond - deletes the bond, releasing its members, and detaches the members attached by createBond. The members
+// are detached even if the bond deletion fails, all the errors are returned
+func (h *hostInterfaces) deleteBond(ctx context.Context, vppConn api.Connection, hostIf *hostInterface) error {
+	var err error
+	if hostIf.swIfIndex != 0 {
+		now := time.Now()
+		if _, delErr := bond.NewServiceClient(vppConn).BondDelete(ctx, &bond.BondDelete{
+			SwIfIndex: hostIf.swIfIndex,
+		}); delErr != nil {
+			// Keep going: the members attached by createBond must be detached anyway
+			err = multierror.Append(err, errors.Wrap(delErr, "vppapi BondDelete returned error"))
+		} else {
+			log.FromContext(ctx).
+				WithField("swIfIndex", hostIf.swIfIndex).
+				WithField("duration", time.Since(now)).
+				WithField("vppapi", "BondDelete").Debug("completed")
+		}
+	}
+	for _, member := range hostIf.members {
+		if !member.attached {
+			continue
+		}
+		if detachErr := h.detach(ctx, vppConn, member.name, member.swIfIndex); detachErr != nil {
+			err = multierror.Append(err, detachErr)
+		}
+	}
+	return err
+}
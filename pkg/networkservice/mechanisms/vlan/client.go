@@ -32,10 +32,12 @@ import (
 	vlanmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vlan"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vlan/l2vtr"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vlan/mtu"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/domain2device"
 )
 
 const (
@@ -44,17 +46,42 @@ const (
 
 type vlanClient struct {
 	vppConn     api.Connection
-	deviceNames map[string]string
+	deviceNames domain2device.Provider
+	domains     map[string]*Domain
+	hostIfs     *hostInterfaces
 }
 
 // NewClient returns a VLAN client chain element
-func NewClient(vppConn api.Connection, domain2Device map[string]string) networkservice.NetworkServiceClient {
+func NewClient(vppConn api.Connection, domain2Device map[string]string, options ...Option) networkservice.NetworkServiceClient {
+	opts := &vlanOptions{
+		domains:       make(map[string]*Domain),
+		domain2Device: domain2device.Map(domain2Device),
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	bonds := make(map[string]*Bond)
+	bondDevices := make(map[string]string)
+	for name, domain := range opts.domains {
+		if domain.Bond != nil {
+			bonds[domain.Bond.name()] = domain.Bond
+			bondDevices[name] = domain.Bond.name()
+		}
+	}
+	deviceNames := &bondProvider{
+		Provider: opts.domain2Device,
+		bonds:    bondDevices,
+	}
+
 	return chain.NewNetworkServiceClient(
-		mtu.NewClient(vppConn, domain2Device),
+		mtu.NewClient(vppConn, deviceNames),
 		l2vtr.NewClient(vppConn),
 		&vlanClient{
 			vppConn:     vppConn,
-			deviceNames: domain2Device,
+			deviceNames: deviceNames,
+			domains:     opts.domains,
+			hostIfs:     newHostInterfaces(opts.attachMode, bonds),
 		},
 	)
 }
@@ -74,7 +101,7 @@ func (v *vlanClient) Request(ctx context.Context, request *networkservice.Networ
 		return nil, err
 	}
 
-	if err := addSubIf(ctx, conn, v.vppConn, v.deviceNames); err != nil {
+	if err := addSubIf(ctx, conn, v.vppConn, v.deviceNames, v.domains, v.hostIfs); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -90,5 +117,10 @@ func (v *vlanClient) Request(ctx context.Context, request *networkservice.Networ
 
 func (v *vlanClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
 	delSubIf(ctx, conn)
+	if device, ok := loadAndDeleteDevice(ctx, true); ok {
+		if err := v.hostIfs.release(ctx, v.vppConn, device); err != nil {
+			log.FromContext(ctx).WithField("vlan", "client").Errorf("failed to detach %s: %s", device, err.Error())
+		}
+	}
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
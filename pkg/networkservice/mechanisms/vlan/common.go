@@ -34,6 +34,8 @@ import (
 	vlanmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vlan"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vlan/l2vtr"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/domain2device"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 )
 
@@ -41,46 +43,63 @@ const (
 	afPacketNamePrefix = "host-"
 )
 
-func addSubIf(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, deviceNames map[string]string) error {
+func addSubIf(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, deviceNames domain2device.Provider, domains map[string]*Domain, hostIfs *hostInterfaces) error {
 	if mechanism := vlanmech.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		_, ok := ifindex.Load(ctx, true)
 		if ok {
 			return nil
 		}
 		via := conn.GetLabels()[viaLabel]
-		hostIFName, ok := deviceNames[via]
+		hostIFName, ok := deviceNames.Device(via)
 		if !ok {
 			return errors.Errorf("no interface name for label %s", via)
 		}
+		if err := hostIfs.acquire(ctx, vppConn, hostIFName); err != nil {
+			return err
+		}
+		storeDevice(ctx, true, hostIFName)
 		vlanID := mechanism.GetVlanID()
-		hostSwIfIndex, vlanSwIfIndex, err := getHostOrVlanInterface(ctx, vppConn, hostIFName, vlanID)
+		if vlanID > maxVlanID {
+			return errors.Errorf("invalid vlan ID %d", vlanID)
+		}
+		domain, ok := domains[via]
+		if !ok {
+			domain = &Domain{}
+		}
+		subIf, ok := domain.subIf(vlanID)
+		if !ok {
+			subIf = &subInterface{}
+		}
+		hostSwIfIndex, vlanSwIfIndex, err := getHostOrVlanInterface(ctx, vppConn, hostIFName, subIf.subID)
 		if err != nil {
 			return err
 		}
-		if vlanID != 0 {
+		if subIf.subID != 0 {
 			if vlanSwIfIndex != 0 {
 				log.FromContext(ctx).
 					WithField("VlanInterfaceIndex", vlanSwIfIndex).Debug("Vlan Interface already created")
 				ifindex.Store(ctx, true, vlanSwIfIndex)
 			} else {
-				newVlanIfIndex, shouldReturn, returnValue := vppAddSubIf(ctx, vppConn, hostSwIfIndex, vlanID)
-				if shouldReturn {
-					return returnValue
+				newVlanIfIndex, err := vppAddSubIf(ctx, vppConn, hostSwIfIndex, subIf)
+				if err != nil {
+					return err
 				}
-				ifindex.Store(ctx, true, *newVlanIfIndex)
+				ifindex.Store(ctx, true, newVlanIfIndex)
 			}
 		} else {
 			log.FromContext(ctx).
 				WithField("HostInterfaceIndex", hostSwIfIndex).Debug("QinQ disabled")
 			ifindex.Store(ctx, true, hostSwIfIndex)
 		}
+		/* Store the number of tags used by the tag rewrite */
+		l2vtr.Store(ctx, true, subIf.tags)
 		/* Store vlanID used by bridge domain server */
 		Store(ctx, true, vlanID)
 	}
 	return nil
 }
 
-func getHostOrVlanInterface(ctx context.Context, vppConn api.Connection, hostIFName string, vlanID uint32) (hostSwIfIndex, vlanSwIfIndex interface_types.InterfaceIndex, err error) {
+func getHostOrVlanInterface(ctx context.Context, vppConn api.Connection, hostIFName string, subID uint32) (hostSwIfIndex, vlanSwIfIndex interface_types.InterfaceIndex, err error) {
 	now := time.Now()
 	client, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{
 		NameFilterValid: true,
@@ -101,7 +120,7 @@ func getHostOrVlanInterface(ctx context.Context, vppConn api.Connection, hostIFN
 		if err != nil {
 			return 0, 0, errors.Wrapf(err, "error attempting to get interface details to set vlan subinterface on %q", hostIFName)
 		}
-		if (vlanID != 0) && strings.Contains(details.InterfaceName, hostIFName) && (details.Type == interface_types.IF_API_TYPE_SUB) && (details.SubID == vlanID) {
+		if (subID != 0) && strings.Contains(details.InterfaceName, hostIFName) && (details.Type == interface_types.IF_API_TYPE_SUB) && (details.SubID == subID) {
 			return 0, details.SwIfIndex, nil
 		}
 		if (hostIFName == details.InterfaceName) || (afPacketNamePrefix+hostIFName == details.InterfaceName) {
@@ -115,24 +134,30 @@ func getHostOrVlanInterface(ctx context.Context, vppConn api.Connection, hostIFN
 	return hostSwIfIndex, 0, nil
 }
 
-func vppAddSubIf(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, vlanID uint32) (*interface_types.InterfaceIndex, bool, error) {
+func vppAddSubIf(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, subIf *subInterface) (interface_types.InterfaceIndex, error) {
 	now := time.Now()
-	vlanSubif := &interfaces.CreateVlanSubif{
-		SwIfIndex: swIfIndex,
-		VlanID:    vlanID,
+	createSubif := &interfaces.CreateSubif{
+		SwIfIndex:   swIfIndex,
+		SubID:       subIf.subID,
+		SubIfFlags:  subIf.flags,
+		OuterVlanID: subIf.outerVlanID,
+		InnerVlanID: subIf.innerVlanID,
 	}
 
-	rsp, err := interfaces.NewServiceClient(vppConn).CreateVlanSubif(ctx, vlanSubif)
+	rsp, err := interfaces.NewServiceClient(vppConn).CreateSubif(ctx, createSubif)
 	if err != nil {
-		return nil, true, errors.Wrap(err, "vppapi CreateVlanSubif returned error")
+		return 0, errors.Wrap(err, "vppapi CreateSubif returned error")
 	}
 	log.FromContext(ctx).
 		WithField("duration", time.Since(now)).
 		WithField("HostInterfaceIndex", swIfIndex).
 		WithField("SubInterfaceIndex", rsp.SwIfIndex).
-		WithField("VlanID", vlanID).
-		WithField("vppapi", "CreateVlanSubIf").Debug("completed")
-	return &rsp.SwIfIndex, false, nil
+		WithField("SubID", subIf.subID).
+		WithField("SubIfFlags", subIf.flags).
+		WithField("OuterVlanID", subIf.outerVlanID).
+		WithField("InnerVlanID", subIf.innerVlanID).
+		WithField("vppapi", "CreateSubif").Debug("completed")
+	return rsp.SwIfIndex, nil
 }
 
 func delSubIf(ctx context.Context, conn *networkservice.Connection) {
@@ -143,6 +168,7 @@ func delSubIf(ctx context.Context, conn *networkservice.Connection) {
 		}
 		/* Delete sub-interface together with the l2 bridge */
 		ifindex.Delete(ctx, true)
+		l2vtr.Delete(ctx, true)
 		Delete(ctx, true)
 	}
 }
@@ -0,0 +1,98 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vlan
+
+import (
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+)
+
+// Match - selects which frames received on the host device are delivered to the sub-interface
+type Match uint8
+
+const (
+	// ExactMatch - the sub-interface receives only the frames carrying exactly its tags
+	ExactMatch Match = iota
+	// DefaultMatch - the sub-interface receives the frames starting with its tags whatever follows them. The
+	// untagged connections get a default sub-interface receiving every frame not matched by another sub-interface
+	// of the device
+	DefaultMatch
+)
+
+const (
+	maxVlanID = 4095
+
+	// sub IDs above the two tags range identify the sub-interfaces without tags
+	untaggedSubID = (maxVlanID+1)*(maxVlanID+1) + iota
+	defaultSubID
+)
+
+// Domain - sub-interface configuration of a vlan domain
+type Domain struct {
+	// OuterVlanID - outer (service) tag of the provider network. The vlan ID of the mechanism becomes the inner tag.
+	// 0 - single tag sub-interfaces
+	OuterVlanID uint16
+	// Dot1ad - the outer tag is an 802.1ad tag instead of an 802.1Q one
+	Dot1ad bool
+	// Match - exact or default matching of the received frames
+	Match Match
+	// Untagged - the untagged connections (vlan ID 0) get an exact match sub-interface receiving only untagged frames
+	// instead of the host device itself, so the tagged sub-interfaces of the device are not shadowed
+	Untagged bool
+	// Bond - the host devices of the domain are aggregated by the bond, the sub-interfaces are created on it
+	Bond *Bond
+}
+
+// subIf - returns the parameters of the sub-interface carrying vlanID, ok is false if the host device itself is used
+func (d *Domain) subIf(vlanID uint32) (subIf *subInterface, ok bool) {
+	subIf = &subInterface{}
+	if d.Dot1ad {
+		subIf.flags |= interface_types.SUB_IF_API_FLAG_DOT1AD
+	}
+	if d.Match == ExactMatch {
+		subIf.flags |= interface_types.SUB_IF_API_FLAG_EXACT_MATCH
+	}
+	switch {
+	case d.OuterVlanID != 0 && vlanID != 0:
+		subIf.subID = uint32(d.OuterVlanID)*(maxVlanID+1) + vlanID
+		subIf.flags |= interface_types.SUB_IF_API_FLAG_TWO_TAGS
+		subIf.outerVlanID, subIf.innerVlanID = d.OuterVlanID, uint16(vlanID)
+		subIf.tags = 2
+	case d.OuterVlanID != 0 || vlanID != 0:
+		subIf.subID = uint32(d.OuterVlanID) + vlanID
+		subIf.flags |= interface_types.SUB_IF_API_FLAG_ONE_TAG
+		subIf.outerVlanID = uint16(subIf.subID)
+		subIf.tags = 1
+	case d.Match == DefaultMatch:
+		subIf.subID = defaultSubID
+		subIf.flags = interface_types.SUB_IF_API_FLAG_DEFAULT
+	case d.Untagged:
+		subIf.subID = untaggedSubID
+		subIf.flags = interface_types.SUB_IF_API_FLAG_NO_TAGS | interface_types.SUB_IF_API_FLAG_EXACT_MATCH
+	default:
+		return nil, false
+	}
+	return subIf, true
+}
+
+type subInterface struct {
+	subID       uint32
+	flags       interface_types.SubIfFlags
+	outerVlanID uint16
+	innerVlanID uint16
+	// tags - number of tags popped on receive and pushed on transmit
+	tags uint8
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vlan
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/govpp/binapi/af_packet"
+	"github.com/networkservicemesh/govpp/binapi/af_xdp"
+	interfaces "github.com/networkservicemesh/govpp/binapi/interface"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+// AttachMode - how the host devices not present in VPP are attached to it
+type AttachMode uint8
+
+const (
+	// AttachNone - the host devices must be attached to VPP in advance
+	AttachNone AttachMode = iota
+	// AttachAfPacket - the host devices are attached on demand as af_packet interfaces
+	AttachAfPacket
+	// AttachAfXDP - the host devices are attached on demand as af_xdp interfaces
+	AttachAfXDP
+)
+
+type hostInterface struct {
+	swIfIndex interface_types.InterfaceIndex
+	// attached - the interface has been created by us and is deleted with the last connection using it
+	attached bool
+	refs     int
+	// members - bond members, if the interface is a bond
+	members []*hostInterface
+	name    string
+}
+
+// hostInterfaces - host devices used by the vlan connections
+type hostInterfaces struct {
+	mode  AttachMode
+	bonds map[string]*Bond
+	ifs   map[string]*hostInterface
+	mu    sync.Mutex
+}
+
+func newHostInterfaces(mode AttachMode, bonds map[string]*Bond) *hostInterfaces {
+	return &hostInterfaces{
+		mode:  mode,
+		bonds: bonds,
+		ifs:   make(map[string]*hostInterface),
+	}
+}
+
+// acquire - attaches the host device to VPP unless it is already there and takes a reference on it
+func (h *hostInterfaces) acquire(ctx context.Context, vppConn api.Connection, hostIFName string) error {
+	bond, isBond := h.bonds[hostIFName]
+	if h.mode == AttachNone && !isBond {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if hostIf, ok := h.ifs[hostIFName]; ok {
+		hostIf.refs++
+		return nil
+	}
+
+	hostIf := &hostInterface{name: hostIFName, refs: 1}
+	swIfIndex, ok, err := findHostInterface(ctx, vppConn, hostIFName)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if isBond {
+			swIfIndex, err = h.createBond(ctx, vppConn, bond, hostIf)
+		} else {
+			swIfIndex, err = h.attach(ctx, vppConn, hostIFName)
+		}
+		if err != nil {
+			return err
+		}
+		hostIf.attached = true
+	}
+	hostIf.swIfIndex = swIfIndex
+	h.ifs[hostIFName] = hostIf
+	return nil
+}
+
+// release - drops the reference on the host device and detaches it from VPP with the last one
+func (h *hostInterfaces) release(ctx context.Context, vppConn api.Connection, hostIFName string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	hostIf, ok := h.ifs[hostIFName]
+	if !ok {
+		return nil
+	}
+	if hostIf.refs--; hostIf.refs > 0 {
+		return nil
+	}
+	delete(h.ifs, hostIFName)
+	if !hostIf.attached {
+		return nil
+	}
+	if _, isBond := h.bonds[hostIFName]; isBond {
+		return h.deleteBond(ctx, vppConn, hostIf)
+	}
+	return h.detach(ctx, vppConn, hostIFName, hostIf.swIfIndex)
+}
+
+func (h *hostInterfaces) attach(ctx context.Context, vppConn api.Connection, hostIFName string) (interface_types.InterfaceIndex, error) {
+	var swIfIndex interface_types.InterfaceIndex
+	now := time.Now()
+	switch h.mode {
+	case AttachAfXDP:
+		rsp, err := af_xdp.NewServiceClient(vppConn).AfXdpCreateV3(ctx, &af_xdp.AfXdpCreateV3{
+			HostIf: hostIFName,
+			Name:   hostIFName,
+			Mode:   af_xdp.AF_XDP_API_MODE_AUTO,
+		})
+		if err != nil {
+			return 0, errors.Wrap(err, "vppapi AfXdpCreateV3 returned error")
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", rsp.SwIfIndex).
+			WithField("HostIf", hostIFName).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "AfXdpCreateV3").Debug("completed")
+		swIfIndex = rsp.SwIfIndex
+	default:
+		hostIf, err := net.InterfaceByName(hostIFName)
+		if err != nil {
+			return 0, errors.Wrapf(err, "host device %s not found", hostIFName)
+		}
+		rsp, err := af_packet.NewServiceClient(vppConn).AfPacketCreateV3(ctx, &af_packet.AfPacketCreateV3{
+			Mode:        af_packet.AF_PACKET_API_MODE_ETHERNET,
+			HostIfName:  hostIFName,
+			HwAddr:      types.ToVppMacAddress(&hostIf.HardwareAddr),
+			RxFrameSize: 10240,
+			TxFrameSize: 10240,
+			Flags:       af_packet.AF_PACKET_API_FLAG_VERSION_2,
+		})
+		if err != nil {
+			return 0, errors.Wrap(err, "vppapi AfPacketCreateV3 returned error")
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", rsp.SwIfIndex).
+			WithField("HostIfName", hostIFName).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "AfPacketCreateV3").Debug("completed")
+		swIfIndex = rsp.SwIfIndex
+	}
+
+	if err := setAdminUp(ctx, vppConn, swIfIndex); err != nil {
+		return 0, err
+	}
+	return swIfIndex, nil
+}
+
+func (h *hostInterfaces) detach(ctx context.Context, vppConn api.Connection, hostIFName string, swIfIndex interface_types.InterfaceIndex) error {
+	now := time.Now()
+	switch h.mode {
+	case AttachAfXDP:
+		if _, err := af_xdp.NewServiceClient(vppConn).AfXdpDelete(ctx, &af_xdp.AfXdpDelete{
+			SwIfIndex: swIfIndex,
+		}); err != nil {
+			return errors.Wrap(err, "vppapi AfXdpDelete returned error")
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", swIfIndex).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "AfXdpDelete").Debug("completed")
+	default:
+		if _, err := af_packet.NewServiceClient(vppConn).AfPacketDelete(ctx, &af_packet.AfPacketDelete{
+			HostIfName: hostIFName,
+		}); err != nil {
+			return errors.Wrap(err, "vppapi AfPacketDelete returned error")
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", swIfIndex).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "AfPacketDelete").Debug("completed")
+	}
+	return nil
+}
+
+func setAdminUp(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) error {
+	now := time.Now()
+	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetFlags(ctx, &interfaces.SwInterfaceSetFlags{
+		SwIfIndex: swIfIndex,
+		Flags:     interface_types.IF_STATUS_API_FLAG_ADMIN_UP,
+	}); err != nil {
+		return errors.Wrap(err, "vppapi SwInterfaceSetFlags returned error")
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetFlags").Debug("completed")
+	return nil
+}
+
+func findHostInterface(ctx context.Context, vppConn api.Connection, hostIFName string) (interface_types.InterfaceIndex, bool, error) {
+	now := time.Now()
+	client, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{
+		NameFilterValid: true,
+		NameFilter:      hostIFName,
+	})
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "error attempting to get interface dump client to find %q", hostIFName)
+	}
+	log.FromContext(ctx).
+		WithField("duration", time.Since(now)).
+		WithField("HostInterfaceName", hostIFName).
+		WithField("vppapi", "SwInterfaceDump").Debug("completed")
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			return 0, false, nil
+		}
+		if err != nil {
+			return 0, false, errors.Wrapf(err, "error attempting to get interface details to find %q", hostIFName)
+		}
+		if (hostIFName == details.InterfaceName) || (afPacketNamePrefix+hostIFName == details.InterfaceName) {
+			return details.SwIfIndex, true, nil
+		}
+	}
+}
@@ -35,17 +35,22 @@ import (
 
 func enableVtr(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection) error {
 	if mechanism := vlanmech.ToMechanism(conn.GetMechanism()); mechanism != nil {
-		if mechanism.GetVlanID() == 0 {
+		tags, ok := Load(ctx, true)
+		if !ok || tags == 0 {
 			return nil
 		}
 		swIfIndex, ok := ifindex.Load(ctx, true)
 		if !ok {
 			return nil
 		}
+		vtrOp, operation := L2VtrPop1, "POP 1"
+		if tags == 2 {
+			vtrOp, operation = L2VtrPop2, "POP 2"
+		}
 		now := time.Now()
 		if _, err := l2.NewServiceClient(vppConn).L2InterfaceVlanTagRewrite(ctx, &l2.L2InterfaceVlanTagRewrite{
 			SwIfIndex: swIfIndex,
-			VtrOp:     L2VtrPop1,
+			VtrOp:     vtrOp,
 			PushDot1q: 0,
 			Tag1:      0,
 			Tag2:      0,
@@ -55,7 +60,7 @@ func enableVtr(ctx context.Context, conn *networkservice.Connection, vppConn api
 		log.FromContext(ctx).
 			WithField("duration", time.Since(now)).
 			WithField("SwIfIndex", swIfIndex).
-			WithField("operation", "POP 1").
+			WithField("operation", operation).
 			WithField("vppapi", "L2InterfaceVlanTagRewrite").Debug("completed")
 	}
 	return nil
@@ -20,5 +20,6 @@ package l2vtr
 const (
 	L2VtrDisabled  uint32 = 0
 	L2VtrPop1      uint32 = 3
+	L2VtrPop2      uint32 = 4
 	L2VtrPushDot1Q uint32 = 1
 )
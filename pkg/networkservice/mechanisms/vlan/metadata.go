@@ -45,3 +45,20 @@ func Load(ctx context.Context, isClient bool) (value uint32, ok bool) {
 	value, ok = rawValue.(uint32)
 	return value, ok
 }
+
+type deviceKey struct{}
+
+// storeDevice sets the host device acquired by the connection
+func storeDevice(ctx context.Context, isClient bool, device string) {
+	metadata.Map(ctx, isClient).Store(deviceKey{}, device)
+}
+
+// loadAndDeleteDevice returns and deletes the host device acquired by the connection
+func loadAndDeleteDevice(ctx context.Context, isClient bool) (value string, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(deviceKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(string)
+	return value, ok
+}
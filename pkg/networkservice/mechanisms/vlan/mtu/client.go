@@ -34,17 +34,18 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vlan"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/domain2device"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 )
 
 type mtuClient struct {
 	vppConn     api.Connection
 	mtu         genericsync.Map[string, uint32]
-	deviceNames map[string]string
+	deviceNames domain2device.Provider
 }
 
 // NewClient - returns client chain element to manage vlan MTU
-func NewClient(vppConn api.Connection, deviceNames map[string]string) networkservice.NetworkServiceClient {
+func NewClient(vppConn api.Connection, deviceNames domain2device.Provider) networkservice.NetworkServiceClient {
 	return &mtuClient{
 		vppConn:     vppConn,
 		deviceNames: deviceNames,
@@ -64,7 +65,7 @@ func (m *mtuClient) Request(ctx context.Context, request *networkservice.Network
 	}
 	if mechanism := vlan.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		via := conn.GetLabels()[viaLabel]
-		hostIFName, ok := m.deviceNames[via]
+		hostIFName, ok := m.deviceNames.Device(via)
 		if !ok {
 			return nil, errors.New("can not find device name for via label")
 		}
@@ -0,0 +1,53 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vlan
+
+import (
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/domain2device"
+)
+
+type vlanOptions struct {
+	domains       map[string]*Domain
+	domain2Device domain2device.Provider
+	attachMode    AttachMode
+}
+
+// Option is an option pattern for vlan client
+type Option func(o *vlanOptions)
+
+// WithDomain sets the sub-interface configuration of the vlan domain, e.g. QinQ outer tag or default matching.
+// The domains without configuration use exact match 802.1Q sub-interfaces
+func WithDomain(name string, domain *Domain) Option {
+	return func(o *vlanOptions) {
+		o.domains[name] = domain
+	}
+}
+
+// WithDomain2DeviceProvider sets the provider of the host devices of the vlan domains, replacing the static mapping
+func WithDomain2DeviceProvider(provider domain2device.Provider) Option {
+	return func(o *vlanOptions) {
+		o.domain2Device = provider
+	}
+}
+
+// WithHostAttach attaches the host devices not present in VPP on demand and detaches them when the last vlan
+// connection using them is closed
+func WithHostAttach(mode AttachMode) Option {
+	return func(o *vlanOptions) {
+		o.attachMode = mode
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package bondstats
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.fd.io/govpp/api"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type bondStatsClient struct {
+	vppConn api.Connection
+}
+
+// NewClient provides a NetworkServiceClient chain elements that collects the bond member state of vlan connections.
+func NewClient(vppConn api.Connection) networkservice.NetworkServiceClient {
+	prometheusInitOnce.Do(registerMetrics)
+	return &bondStatsClient{
+		vppConn: vppConn,
+	}
+}
+
+func (s *bondStatsClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	retrieveMetrics(ctx, s.vppConn, conn, metadata.IsClient(s))
+	return conn, nil
+}
+
+func (s *bondStatsClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	stopMetrics(ctx, metadata.IsClient(s))
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package bondstats
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vlan"
+	"github.com/networkservicemesh/govpp/binapi/bond"
+	interfaces "github.com/networkservicemesh/govpp/binapi/interface"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/govpp/binapi/lacp"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/prometheus"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+const (
+	serverPref = "server_"
+	clientPref = "client_"
+)
+
+type memberState struct {
+	name             string
+	up               bool
+	lacp             bool
+	lacpActorState   uint8
+	lacpPartnerState uint8
+	lacpRxState      uint32
+	lacpMuxState     uint32
+}
+
+type bondState struct {
+	name          string
+	members       uint32
+	activeMembers uint32
+	memberStates  []*memberState
+}
+
+// dumpBondState - returns the state of the bond the sub-interface belongs to, ok is false if it is not on a bond
+func dumpBondState(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) (state *bondState, ok bool, err error) {
+	ifs, err := dumpInterfaces(ctx, vppConn)
+	if err != nil {
+		return nil, false, err
+	}
+	sub, ok := ifs[swIfIndex]
+	if !ok {
+		return nil, false, nil
+	}
+	bondSwIfIndex := interface_types.InterfaceIndex(sub.SupSwIfIndex)
+
+	bondClient, err := bond.NewServiceClient(vppConn).SwBondInterfaceDump(ctx, &bond.SwBondInterfaceDump{
+		SwIfIndex: bondSwIfIndex,
+	})
+	if err != nil {
+		return nil, false, errors.Wrap(err, "vppapi SwBondInterfaceDump returned error")
+	}
+	defer func() { _ = bondClient.Close() }()
+	bondDetails, err := bondClient.Recv()
+	if err == io.EOF {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, errors.Wrap(err, "vppapi SwBondInterfaceDump returned error")
+	}
+	state = &bondState{
+		name:          bondDetails.InterfaceName,
+		members:       bondDetails.Members,
+		activeMembers: bondDetails.ActiveMembers,
+	}
+
+	memberClient, err := bond.NewServiceClient(vppConn).SwMemberInterfaceDump(ctx, &bond.SwMemberInterfaceDump{
+		SwIfIndex: bondSwIfIndex,
+	})
+	if err != nil {
+		return nil, false, errors.Wrap(err, "vppapi SwMemberInterfaceDump returned error")
+	}
+	defer func() { _ = memberClient.Close() }()
+	members := make(map[string]*memberState)
+	for {
+		details, err := memberClient.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, false, errors.Wrap(err, "vppapi SwMemberInterfaceDump returned error")
+		}
+		member := &memberState{
+			name: details.InterfaceName,
+		}
+		if memberIf, ok := ifs[details.SwIfIndex]; ok {
+			member.up = memberIf.Flags&interface_types.IF_STATUS_API_FLAG_LINK_UP != 0
+		}
+		members[member.name] = member
+		state.memberStates = append(state.memberStates, member)
+	}
+
+	if bondDetails.Mode == bond.BOND_API_MODE_LACP {
+		if err := fillLacpState(ctx, vppConn, state.name, members); err != nil {
+			return nil, false, err
+		}
+	}
+	return state, true, nil
+}
+
+func dumpInterfaces(ctx context.Context, vppConn api.Connection) (map[interface_types.InterfaceIndex]*interfaces.SwInterfaceDetails, error) {
+	client, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{
+		SwIfIndex: ^interface_types.InterfaceIndex(0),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "vppapi SwInterfaceDump returned error")
+	}
+	defer func() { _ = client.Close() }()
+
+	ifs := make(map[interface_types.InterfaceIndex]*interfaces.SwInterfaceDetails)
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			return ifs, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "vppapi SwInterfaceDump returned error")
+		}
+		ifs[details.SwIfIndex] = details
+	}
+}
+
+func fillLacpState(ctx context.Context, vppConn api.Connection, bondName string, members map[string]*memberState) error {
+	client, err := lacp.NewServiceClient(vppConn).SwInterfaceLacpDump(ctx, &lacp.SwInterfaceLacpDump{})
+	if err != nil {
+		return errors.Wrap(err, "vppapi SwInterfaceLacpDump returned error")
+	}
+	defer func() { _ = client.Close() }()
+
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "vppapi SwInterfaceLacpDump returned error")
+		}
+		if details.BondInterfaceName != bondName {
+			continue
+		}
+		if member, ok := members[details.InterfaceName]; ok {
+			member.lacp = true
+			member.lacpActorState = details.ActorState
+			member.lacpPartnerState = details.PartnerState
+			member.lacpRxState = details.RxState
+			member.lacpMuxState = details.MuxState
+		}
+	}
+}
+
+// fill - saves the bond state in the path segment metrics
+func (s *bondState) fill(segment *networkservice.PathSegment, isClient bool) {
+	addName := serverPref
+	if isClient {
+		addName = clientPref
+	}
+	if segment.Metrics == nil {
+		segment.Metrics = make(map[string]string)
+	}
+	segment.Metrics[addName+"bond"] = s.name
+	segment.Metrics[addName+"bond_members"] = strconv.FormatUint(uint64(s.members), 10)
+	segment.Metrics[addName+"bond_active_members"] = strconv.FormatUint(uint64(s.activeMembers), 10)
+	for _, member := range s.memberStates {
+		memberName := addName + "bond_member_" + member.name
+		segment.Metrics[memberName+"_up"] = strconv.FormatBool(member.up)
+		if member.lacp {
+			segment.Metrics[memberName+"_lacp_actor_state"] = strconv.FormatUint(uint64(member.lacpActorState), 16)
+			segment.Metrics[memberName+"_lacp_partner_state"] = strconv.FormatUint(uint64(member.lacpPartnerState), 16)
+			segment.Metrics[memberName+"_lacp_rx_state"] = strconv.FormatUint(uint64(member.lacpRxState), 10)
+			segment.Metrics[memberName+"_lacp_mux_state"] = strconv.FormatUint(uint64(member.lacpMuxState), 10)
+		}
+	}
+}
+
+// retrieveMetrics - saves the state of the bond carrying the vlan connection in the path segment
+func retrieveMetrics(ctx context.Context, vppConn api.Connection, conn *networkservice.Connection, isClient bool) {
+	if vlan.ToMechanism(conn.GetMechanism()) == nil {
+		return
+	}
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return
+	}
+	state, ok, err := dumpBondState(ctx, vppConn, swIfIndex)
+	if err != nil {
+		log.FromContext(ctx).WithField("swIfIndex", swIfIndex).Debugf("failed to collect bond state: %v", err)
+		return
+	}
+	if !ok {
+		return
+	}
+	state.fill(conn.GetPath().GetPathSegments()[conn.GetPath().GetIndex()], isClient)
+
+	if prometheus.IsEnabled() {
+		stopMetrics(ctx, isClient)
+		side := strings.TrimSuffix(serverPref, "_")
+		if isClient {
+			side = strings.TrimSuffix(clientPref, "_")
+		}
+		labels := &bondLabels{
+			bond: []string{conn.GetId(), conn.GetNetworkService(), conn.GetPath().GetPathSegments()[0].GetId(), side, state.name},
+		}
+		for _, member := range state.memberStates {
+			labels.members = append(labels.members, append(append([]string{}, labels.bond...), member.name))
+		}
+		updateMetrics(labels, state)
+		store(ctx, isClient, labels)
+	}
+}
+
+// stopMetrics - deletes the prometheus metrics of the connection
+func stopMetrics(ctx context.Context, isClient bool) {
+	if labels, ok := loadAndDelete(ctx, isClient); ok {
+		deleteMetrics(labels)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package bondstats
+
+import (
+	"os"
+	"sync"
+
+	prom "github.com/networkservicemesh/sdk/pkg/tools/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	prometheusInitOnce sync.Once
+
+	bondMembers       *prometheus.GaugeVec
+	bondActiveMembers *prometheus.GaugeVec
+	bondMemberUp      *prometheus.GaugeVec
+)
+
+// bondLabels - prometheus labels of the connection and of the bond members
+type bondLabels struct {
+	bond    []string
+	members [][]string
+}
+
+func registerMetrics() {
+	if prom.IsEnabled() {
+		prefix := os.Getenv("PROMETHEUS_METRICS_PREFIX")
+		if prefix != "" {
+			prefix += "_"
+		}
+		labels := []string{"connection_id", "network_service", "nsc", "side", "bond"}
+		bondMembers = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prefix + "bond_members",
+				Help: "Number of the members of the bond carrying the vlan connection.",
+			}, labels)
+		bondActiveMembers = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prefix + "bond_active_members",
+				Help: "Number of the active members of the bond carrying the vlan connection.",
+			}, labels)
+		bondMemberUp = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prefix + "bond_member_up",
+				Help: "Link state of the member of the bond carrying the vlan connection.",
+			}, append(labels, "member"))
+		prometheus.MustRegister(bondMembers, bondActiveMembers, bondMemberUp)
+	}
+}
+
+func updateMetrics(labels *bondLabels, state *bondState) {
+	bondMembers.WithLabelValues(labels.bond...).Set(float64(state.members))
+	bondActiveMembers.WithLabelValues(labels.bond...).Set(float64(state.activeMembers))
+	for i, member := range state.memberStates {
+		up := 0.
+		if member.up {
+			up = 1
+		}
+		bondMemberUp.WithLabelValues(labels.members[i]...).Set(up)
+	}
+}
+
+func deleteMetrics(labels *bondLabels) {
+	bondMembers.DeleteLabelValues(labels.bond...)
+	bondActiveMembers.DeleteLabelValues(labels.bond...)
+	for _, memberLabels := range labels.members {
+		bondMemberUp.DeleteLabelValues(memberLabels...)
+	}
+}
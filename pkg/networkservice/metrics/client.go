@@ -28,11 +28,13 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/metrics/bondstats"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/metrics/ifacename"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/metrics/ipsecstats"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/metrics/stats"
 )
 
-// NewClient provides a NetworkServiceClient chain elements that retrieves vpp interface metrics and names.
+// NewClient provides a NetworkServiceClient chain elements that retrieves vpp interface metrics, names, IPSec SA state and bond member state.
 func NewClient(ctx context.Context, vppConn api.Connection, options ...Option) networkservice.NetworkServiceClient {
 	opts := &metricsOptions{}
 	for _, opt := range options {
@@ -42,5 +44,7 @@ func NewClient(ctx context.Context, vppConn api.Connection, options ...Option) n
 	return chain.NewNetworkServiceClient(
 		stats.NewClient(ctx, stats.WithSocket(opts.socket)),
 		ifacename.NewClient(ctx, vppConn, ifacename.WithSocket(opts.socket)),
+		ipsecstats.NewClient(ctx, vppConn),
+		bondstats.NewClient(vppConn),
 	)
 }
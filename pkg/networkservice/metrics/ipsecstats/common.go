@@ -0,0 +1,266 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipsecstats
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	ipsecapi "github.com/networkservicemesh/govpp/binapi/ipsec"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/prometheus"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/ipsec/staticsa"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+const (
+	defaultInterval = 15 * time.Second
+	serverPref      = "server_"
+	clientPref      = "client_"
+)
+
+// saState - state of the IKEv2 SA and the child SA protecting the IPSec interface.
+// VPP doesn't report the child SA rekeys and lifetime: childRekeys counts the child SA SPI changes seen between
+// the collections and lifetimeRemaining is estimated from the time the current child SA was first seen.
+type saState struct {
+	ikeISpi           uint64
+	ikeRSpi           uint64
+	childISpi         uint32
+	childRSpi         uint32
+	ikeRekeyRequests  uint16
+	ikeKeepalives     uint16
+	childRekeys       uint64
+	lifetimeRemaining time.Duration
+	espTxPackets      uint64
+	espRxLastSeq      uint64
+}
+
+// saMonitor - periodically collects saState of the connection
+type saMonitor struct {
+	vppConn    api.Connection
+	ikeSas     *ikeSaCache
+	swIfIndex  interface_types.InterfaceIndex
+	saLifetime time.Duration
+	labels     []string
+	cancel     context.CancelFunc
+
+	mu            sync.Mutex
+	state         *saState
+	childSpi      uint32
+	childObserved time.Time
+	childRekeys   uint64
+}
+
+func newSaMonitor(chainCtx context.Context, vppConn api.Connection, ikeSas *ikeSaCache, swIfIndex interface_types.InterfaceIndex, opts *ipsecStatsOptions, labels []string) *saMonitor {
+	ctx, cancel := context.WithCancel(chainCtx)
+	m := &saMonitor{
+		vppConn:    vppConn,
+		ikeSas:     ikeSas,
+		swIfIndex:  swIfIndex,
+		saLifetime: opts.saLifetime,
+		labels:     labels,
+		cancel:     cancel,
+	}
+	go func() {
+		ticker := time.NewTicker(opts.interval)
+		defer ticker.Stop()
+		for {
+			m.collect(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return m
+}
+
+func (m *saMonitor) collect(ctx context.Context) {
+	state, err := dumpSaState(ctx, m.vppConn, m.swIfIndex, m.ikeSas)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.FromContext(ctx).WithField("swIfIndex", m.swIfIndex).Debugf("failed to collect IPSec SA state: %v", err)
+		}
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if ctx.Err() != nil {
+		return
+	}
+	if state.childISpi != m.childSpi {
+		if m.childSpi != 0 {
+			m.childRekeys++
+		}
+		m.childSpi = state.childISpi
+		m.childObserved = time.Now()
+	}
+	state.childRekeys = m.childRekeys
+	state.lifetimeRemaining = m.saLifetime - time.Since(m.childObserved)
+	if state.lifetimeRemaining < 0 {
+		state.lifetimeRemaining = 0
+	}
+	m.state = state
+
+	if prometheus.IsEnabled() {
+		updateMetrics(m.labels, state)
+	}
+}
+
+// fill - saves the last collected state in the path segment metrics
+func (m *saMonitor) fill(segment *networkservice.PathSegment, isClient bool) {
+	m.mu.Lock()
+	state := m.state
+	m.mu.Unlock()
+	if state == nil {
+		return
+	}
+
+	addName := serverPref
+	if isClient {
+		addName = clientPref
+	}
+	if segment.Metrics == nil {
+		segment.Metrics = make(map[string]string)
+	}
+	segment.Metrics[addName+"ike_ispi"] = strconv.FormatUint(state.ikeISpi, 16)
+	segment.Metrics[addName+"ike_rspi"] = strconv.FormatUint(state.ikeRSpi, 16)
+	segment.Metrics[addName+"child_sa_ispi"] = strconv.FormatUint(uint64(state.childISpi), 16)
+	segment.Metrics[addName+"child_sa_rspi"] = strconv.FormatUint(uint64(state.childRSpi), 16)
+	segment.Metrics[addName+"child_sa_rekeys_observed"] = strconv.FormatUint(state.childRekeys, 10)
+	segment.Metrics[addName+"child_sa_lifetime_remaining_estimate"] = strconv.FormatInt(int64(state.lifetimeRemaining.Seconds()), 10)
+	segment.Metrics[addName+"esp_tx_packets"] = strconv.FormatUint(state.espTxPackets, 10)
+	segment.Metrics[addName+"esp_rx_last_seq"] = strconv.FormatUint(state.espRxLastSeq, 10)
+}
+
+func (m *saMonitor) stop() {
+	m.cancel()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if prometheus.IsEnabled() {
+		deleteMetrics(m.labels)
+	}
+}
+
+func dumpSaState(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, ikeSas *ikeSaCache) (*saState, error) {
+	protectClient, err := ipsecapi.NewServiceClient(vppConn).IpsecTunnelProtectDump(ctx, &ipsecapi.IpsecTunnelProtectDump{
+		SwIfIndex: swIfIndex,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "vppapi IpsecTunnelProtectDump returned error")
+	}
+	defer func() { _ = protectClient.Close() }()
+
+	protect, err := protectClient.Recv()
+	if err == io.EOF {
+		return nil, errors.Errorf("interface %d is not protected", swIfIndex)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "vppapi IpsecTunnelProtectDump returned error")
+	}
+
+	state := &saState{}
+	outSa, err := dumpSa(ctx, vppConn, protect.Tun.SaOut)
+	if err != nil {
+		return nil, err
+	}
+	state.espTxPackets = outSa.SeqOutbound
+	spis := map[uint32]struct{}{outSa.Entry.Spi: {}}
+	for _, saID := range protect.Tun.SaIn {
+		inSa, err := dumpSa(ctx, vppConn, saID)
+		if err != nil {
+			return nil, err
+		}
+		if inSa.LastSeqInbound > state.espRxLastSeq {
+			state.espRxLastSeq = inSa.LastSeqInbound
+		}
+		spis[inSa.Entry.Spi] = struct{}{}
+	}
+
+	if err := ikeSas.fill(ctx, spis, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func dumpSa(ctx context.Context, vppConn api.Connection, saID uint32) (*ipsecapi.IpsecSaV4Details, error) {
+	client, err := ipsecapi.NewServiceClient(vppConn).IpsecSaV4Dump(ctx, &ipsecapi.IpsecSaV4Dump{SaID: saID})
+	if err != nil {
+		return nil, errors.Wrap(err, "vppapi IpsecSaV4Dump returned error")
+	}
+	defer func() { _ = client.Close() }()
+
+	details, err := client.Recv()
+	if err == io.EOF {
+		return nil, errors.Errorf("SA %d is not found", saID)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "vppapi IpsecSaV4Dump returned error")
+	}
+	return details, nil
+}
+
+// retrieveMetrics - starts saMonitor for the IPSec connection and saves the last collected SA state in the path segment
+func retrieveMetrics(ctx, chainCtx context.Context, vppConn api.Connection, ikeSas *ikeSaCache, conn *networkservice.Connection, opts *ipsecStatsOptions, isClient bool) {
+	if ipsec.ToMechanism(conn.GetMechanism()) == nil || staticsa.IsStatic(conn.GetMechanism()) {
+		return
+	}
+	segments := conn.GetPath().GetPathSegments()
+	if int(conn.GetPath().GetIndex()) >= len(segments) {
+		return
+	}
+	m, ok := load(ctx, isClient)
+	if !ok {
+		swIfIndex, ok := ifindex.Load(ctx, isClient)
+		if !ok {
+			return
+		}
+		side := strings.TrimSuffix(serverPref, "_")
+		if isClient {
+			side = strings.TrimSuffix(clientPref, "_")
+		}
+		labels := []string{conn.GetId(), conn.GetNetworkService(), segments[0].GetId(), side}
+		m = newSaMonitor(chainCtx, vppConn, ikeSas, swIfIndex, opts, labels)
+		store(ctx, isClient, m)
+	}
+	m.fill(segments[conn.GetPath().GetIndex()], isClient)
+}
+
+// stopMetrics - stops saMonitor of the connection
+func stopMetrics(ctx context.Context, isClient bool) {
+	if m, ok := loadAndDelete(ctx, isClient); ok {
+		m.stop()
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ipsecstats provides chain elements periodically collecting the state of the IKEv2 SA and child SA
+// (SPIs, ESP counters, IKEv2 SA statistics) of the IPSec connections based on IKEv2.
+// VPP doesn't report the child SA lifetime and rekeys, so the remaining lifetime and the rekeys count are estimated
+// from the configured SA lifetime and the child SA SPI changes seen between the collections
+package ipsecstats
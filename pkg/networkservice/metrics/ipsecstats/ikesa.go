@@ -0,0 +1,135 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipsecstats
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/govpp/binapi/ikev2"
+	"github.com/networkservicemesh/govpp/binapi/ikev2_types"
+)
+
+// ikeChildSa - the child SA and the IKEv2 SA owning it
+type ikeChildSa struct {
+	sa    ikev2_types.Ikev2Sa
+	child ikev2_types.Ikev2ChildSa
+}
+
+// ikeSaCache - the IKEv2 SAs and their child SAs dumped at most once per interval and shared by all the connections
+// of the chain element, so that every connection doesn't dump all the SAs of VPP
+type ikeSaCache struct {
+	vppConn  api.Connection
+	interval time.Duration
+
+	mu     sync.Mutex
+	dumped time.Time
+	bySpi  map[uint32]*ikeChildSa
+}
+
+func newIkeSaCache(vppConn api.Connection, interval time.Duration) *ikeSaCache {
+	return &ikeSaCache{
+		vppConn:  vppConn,
+		interval: interval,
+	}
+}
+
+// fill - finds the IKEv2 SA owning the child SA with one of the given SPIs
+func (c *ikeSaCache) fill(ctx context.Context, spis map[uint32]struct{}, state *saState) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.bySpi == nil || time.Since(c.dumped) >= c.interval {
+		bySpi, err := dumpIkeSas(ctx, c.vppConn)
+		if err != nil {
+			return err
+		}
+		c.bySpi = bySpi
+		c.dumped = time.Now()
+	}
+
+	for spi := range spis {
+		if s, ok := c.bySpi[spi]; ok {
+			state.ikeISpi = s.sa.Ispi
+			state.ikeRSpi = s.sa.Rspi
+			state.ikeRekeyRequests = s.sa.Stats.NRekeyReq
+			state.ikeKeepalives = s.sa.Stats.NKeepalives
+			state.childISpi = s.child.ISpi
+			state.childRSpi = s.child.RSpi
+			return nil
+		}
+	}
+	return errors.New("IKEv2 SA is not found")
+}
+
+// dumpIkeSas - returns all the child SAs of VPP by their initiator and responder SPIs
+func dumpIkeSas(ctx context.Context, vppConn api.Connection) (map[uint32]*ikeChildSa, error) {
+	client, err := ikev2.NewServiceClient(vppConn).Ikev2SaDump(ctx, &ikev2.Ikev2SaDump{})
+	if err != nil {
+		return nil, errors.Wrap(err, "vppapi Ikev2SaDump returned error")
+	}
+	defer func() { _ = client.Close() }()
+
+	var sas []ikev2_types.Ikev2Sa
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "vppapi Ikev2SaDump returned error")
+		}
+		sas = append(sas, details.Sa)
+	}
+
+	bySpi := make(map[uint32]*ikeChildSa)
+	for _, sa := range sas {
+		if err := dumpChildSas(ctx, vppConn, sa, bySpi); err != nil {
+			return nil, err
+		}
+	}
+	return bySpi, nil
+}
+
+func dumpChildSas(ctx context.Context, vppConn api.Connection, sa ikev2_types.Ikev2Sa, bySpi map[uint32]*ikeChildSa) error {
+	client, err := ikev2.NewServiceClient(vppConn).Ikev2ChildSaDump(ctx, &ikev2.Ikev2ChildSaDump{SaIndex: sa.SaIndex})
+	if err != nil {
+		return errors.Wrap(err, "vppapi Ikev2ChildSaDump returned error")
+	}
+	defer func() { _ = client.Close() }()
+
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "vppapi Ikev2ChildSaDump returned error")
+		}
+		s := &ikeChildSa{sa: sa, child: details.ChildSa}
+		bySpi[details.ChildSa.ISpi] = s
+		bySpi[details.ChildSa.RSpi] = s
+	}
+}
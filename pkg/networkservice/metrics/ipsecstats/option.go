@@ -0,0 +1,42 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsecstats
+
+import "time"
+
+type ipsecStatsOptions struct {
+	interval   time.Duration
+	saLifetime time.Duration
+}
+
+// Option is an option pattern for ipsecstats server/client
+type Option func(o *ipsecStatsOptions)
+
+// WithInterval sets the SA state collection interval
+func WithInterval(interval time.Duration) Option {
+	return func(o *ipsecStatsOptions) {
+		o.interval = interval
+	}
+}
+
+// WithSaLifetime sets the lifetime of the child SA used to estimate the remaining lifetime.
+// Default: ipsec.SaLifetime
+func WithSaLifetime(saLifetime time.Duration) Option {
+	return func(o *ipsecStatsOptions) {
+		o.saLifetime = saLifetime
+	}
+}
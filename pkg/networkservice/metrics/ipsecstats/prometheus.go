@@ -0,0 +1,100 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipsecstats
+
+import (
+	"os"
+	"sync"
+
+	prom "github.com/networkservicemesh/sdk/pkg/tools/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	prometheusInitOnce sync.Once
+
+	childSaLifetimeRemaining *prometheus.GaugeVec
+	childSaRekeys            *prometheus.GaugeVec
+	ikeRekeyRequests         *prometheus.GaugeVec
+	ikeKeepalives            *prometheus.GaugeVec
+	espTxPackets             *prometheus.GaugeVec
+	espRxLastSeq             *prometheus.GaugeVec
+)
+
+func newGaugeVec(name, help string) *prometheus.GaugeVec {
+	vec := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: name,
+			Help: help,
+		}, []string{"connection_id", "network_service", "nsc", "side"})
+	prometheus.MustRegister(vec)
+	return vec
+}
+
+func registerMetrics() {
+	if prom.IsEnabled() {
+		prefix := os.Getenv("PROMETHEUS_METRICS_PREFIX")
+		if prefix != "" {
+			prefix += "_"
+		}
+		childSaLifetimeRemaining = newGaugeVec(
+			prefix+"ipsec_child_sa_lifetime_remaining_estimate_seconds",
+			"Estimated remaining lifetime of the IPSec child SA: the configured SA lifetime minus the time since the child SA was first seen.",
+		)
+		childSaRekeys = newGaugeVec(
+			prefix+"ipsec_child_sa_rekeys_observed",
+			"Number of the IPSec child SA rekeys seen between the collections, several rekeys within one interval count as one.",
+		)
+		ikeRekeyRequests = newGaugeVec(
+			prefix+"ipsec_ike_sa_rekey_requests",
+			"Number of the rekey requests of the IKEv2 SA.",
+		)
+		ikeKeepalives = newGaugeVec(
+			prefix+"ipsec_ike_sa_keepalives",
+			"Number of the liveness checks of the IKEv2 SA.",
+		)
+		espTxPackets = newGaugeVec(
+			prefix+"ipsec_esp_tx_packets",
+			"Number of the ESP packets sent by the outbound IPSec SA.",
+		)
+		espRxLastSeq = newGaugeVec(
+			prefix+"ipsec_esp_rx_last_seq",
+			"Last sequence number received by the inbound IPSec SA.",
+		)
+	}
+}
+
+func updateMetrics(labels []string, state *saState) {
+	childSaLifetimeRemaining.WithLabelValues(labels...).Set(state.lifetimeRemaining.Seconds())
+	childSaRekeys.WithLabelValues(labels...).Set(float64(state.childRekeys))
+	ikeRekeyRequests.WithLabelValues(labels...).Set(float64(state.ikeRekeyRequests))
+	ikeKeepalives.WithLabelValues(labels...).Set(float64(state.ikeKeepalives))
+	espTxPackets.WithLabelValues(labels...).Set(float64(state.espTxPackets))
+	espRxLastSeq.WithLabelValues(labels...).Set(float64(state.espRxLastSeq))
+}
+
+func deleteMetrics(labels []string) {
+	childSaLifetimeRemaining.DeleteLabelValues(labels...)
+	childSaRekeys.DeleteLabelValues(labels...)
+	ikeRekeyRequests.DeleteLabelValues(labels...)
+	ikeKeepalives.DeleteLabelValues(labels...)
+	espTxPackets.DeleteLabelValues(labels...)
+	espRxLastSeq.DeleteLabelValues(labels...)
+}
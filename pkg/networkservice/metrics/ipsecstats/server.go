@@ -0,0 +1,74 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipsecstats
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/ipsec"
+)
+
+type ipsecStatsServer struct {
+	chainCtx context.Context
+	vppConn  api.Connection
+	ikeSas   *ikeSaCache
+	opts     *ipsecStatsOptions
+}
+
+// NewServer provides a NetworkServiceServer chain elements that periodically collects IPSec SA state.
+func NewServer(ctx context.Context, vppConn api.Connection, options ...Option) networkservice.NetworkServiceServer {
+	prometheusInitOnce.Do(registerMetrics)
+	opts := &ipsecStatsOptions{
+		interval:   defaultInterval,
+		saLifetime: ipsec.SaLifetime,
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	return &ipsecStatsServer{
+		chainCtx: ctx,
+		vppConn:  vppConn,
+		ikeSas:   newIkeSaCache(vppConn, opts.interval),
+		opts:     opts,
+	}
+}
+
+func (s *ipsecStatsServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	retrieveMetrics(ctx, s.chainCtx, s.vppConn, s.ikeSas, conn, s.opts, metadata.IsClient(s))
+	return conn, nil
+}
+
+func (s *ipsecStatsServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	stopMetrics(ctx, metadata.IsClient(s))
+	return next.Server(ctx).Close(ctx, conn)
+}
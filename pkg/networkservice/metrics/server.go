@@ -28,10 +28,11 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/metrics/ifacename"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/metrics/ipsecstats"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/metrics/stats"
 )
 
-// NewServer provides NetworkServiceServer chain elements that retrieve vpp interface statistics and names.
+// NewServer provides NetworkServiceServer chain elements that retrieve vpp interface statistics, names and IPSec SA state.
 func NewServer(ctx context.Context, vppConn api.Connection, options ...Option) networkservice.NetworkServiceServer {
 	opts := &metricsOptions{}
 	for _, opt := range options {
@@ -41,5 +42,6 @@ func NewServer(ctx context.Context, vppConn api.Connection, options ...Option) n
 	return chain.NewNetworkServiceServer(
 		stats.NewServer(ctx, stats.WithSocket(opts.socket)),
 		ifacename.NewServer(ctx, vppConn, ifacename.WithSocket(opts.socket)),
+		ipsecstats.NewServer(ctx, vppConn),
 	)
 }
@@ -0,0 +1,157 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nat
+
+import (
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/govpp/binapi/nat44_ed"
+	"github.com/networkservicemesh/govpp/binapi/nat_types"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+const (
+	sessionsMetric = "nat44_sessions"
+)
+
+func insideAddDel(ctx context.Context, vppConn api.Connection, g *gateway, swIfIndex interface_types.InterfaceIndex, isAdd bool) error {
+	if g.nat44() {
+		if err := nat44InterfaceAddDel(ctx, vppConn, swIfIndex, nat_types.NAT_IS_INSIDE, isAdd); err != nil {
+			return err
+		}
+	}
+	if g.nat66() {
+		if err := nat66InterfaceAddDel(ctx, vppConn, swIfIndex, nat_types.NAT_IS_INSIDE, isAdd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insideIPs - returns the IPv4 addresses of the client of the connection
+func insideIPs(conn *networkservice.Connection) []net.IP {
+	var rv []net.IP
+	for _, ipNet := range conn.GetContext().GetIpContext().GetSrcIPNets() {
+		if ipNet != nil && ipNet.IP.To4() != nil {
+			rv = append(rv, ipNet.IP)
+		}
+	}
+	return rv
+}
+
+// countSessions - returns the number of the NAT44 sessions of the inside addresses
+func countSessions(ctx context.Context, vppConn api.Connection, ips []net.IP, vrfID uint32) (uint32, error) {
+	now := time.Now()
+	client, err := nat44_ed.NewServiceClient(vppConn).Nat44UserDump(ctx, &nat44_ed.Nat44UserDump{})
+	if err != nil {
+		return 0, errors.Wrap(err, "vppapi Nat44UserDump returned error")
+	}
+	defer func() { _ = client.Close() }()
+
+	var sessions uint32
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, errors.Wrap(err, "vppapi Nat44UserDump returned error")
+		}
+		if details.VrfID != vrfID {
+			continue
+		}
+		for _, ip := range ips {
+			if details.IPAddress.ToIP().Equal(ip) {
+				sessions += details.Nsessions + details.Nstaticsessions
+			}
+		}
+	}
+	log.FromContext(ctx).
+		WithField("sessions", sessions).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "Nat44UserDump").Debug("completed")
+	return sessions, nil
+}
+
+// delSessions - deletes the NAT44 sessions of the inside address
+func delSessions(ctx context.Context, vppConn api.Connection, ip net.IP, vrfID uint32) error {
+	client, err := nat44_ed.NewServiceClient(vppConn).Nat44UserSessionV3Dump(ctx, &nat44_ed.Nat44UserSessionV3Dump{
+		IPAddress: types.ToVppIP4Address(ip),
+		VrfID:     vrfID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "vppapi Nat44UserSessionV3Dump returned error")
+	}
+	var sessions []*nat44_ed.Nat44UserSessionV3Details
+	for {
+		details, recvErr := client.Recv()
+		if recvErr == io.EOF {
+			break
+		}
+		if recvErr != nil {
+			_ = client.Close()
+			return errors.Wrap(recvErr, "vppapi Nat44UserSessionV3Dump returned error")
+		}
+		sessions = append(sessions, details)
+	}
+	_ = client.Close()
+
+	for _, session := range sessions {
+		now := time.Now()
+		if _, delErr := nat44_ed.NewServiceClient(vppConn).Nat44DelSession(ctx, &nat44_ed.Nat44DelSession{
+			Address:        session.InsideIPAddress,
+			Protocol:       uint8(session.Protocol),
+			Port:           session.InsidePort,
+			VrfID:          vrfID,
+			Flags:          nat_types.NAT_IS_INSIDE | nat_types.NAT_IS_EXT_HOST_VALID,
+			ExtHostAddress: session.ExtHostAddress,
+			ExtHostPort:    session.ExtHostPort,
+		}); delErr != nil {
+			err = multierror.Append(err, errors.Wrap(delErr, "vppapi Nat44DelSession returned error"))
+			continue
+		}
+		log.FromContext(ctx).
+			WithField("inside", session.InsideIPAddress).
+			WithField("insidePort", session.InsidePort).
+			WithField("protocol", session.Protocol).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "Nat44DelSession").Debug("completed")
+	}
+	return err
+}
+
+// fillSessions - saves the number of the NAT44 sessions of the connection in the path segment metrics
+func fillSessions(conn *networkservice.Connection, sessions uint32) {
+	segment := conn.GetPath().GetPathSegments()[conn.GetPath().GetIndex()]
+	if segment.Metrics == nil {
+		segment.Metrics = make(map[string]string)
+	}
+	segment.Metrics[sessionsMetric] = strconv.FormatUint(uint64(sessions), 10)
+}
@@ -0,0 +1,360 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nat
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	interfaces "github.com/networkservicemesh/govpp/binapi/interface"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/govpp/binapi/ip_types"
+	"github.com/networkservicemesh/govpp/binapi/nat44_ed"
+	"github.com/networkservicemesh/govpp/binapi/nat66"
+	"github.com/networkservicemesh/govpp/binapi/nat_types"
+	"github.com/networkservicemesh/govpp/binapi/npt66"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+const (
+	natTag = "nsm-nat"
+)
+
+// gateway - the NAT configuration shared by the connections. It is created with the first connection and deleted
+// with the last one. The nat44-ed and nat66 plugins are global for VPP and may be used by others, so they are enabled
+// once and never disabled
+type gateway struct {
+	opts      *options
+	refs      int
+	outside   interface_types.InterfaceIndex
+	nat44Used bool
+	nat66Used bool
+	mu        sync.Mutex
+}
+
+func (g *gateway) nat44() bool {
+	if len(g.opts.pool) > 0 {
+		return true
+	}
+	for _, m := range g.opts.staticMappings {
+		if !m.isIPv6() {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *gateway) nat66() bool {
+	for _, m := range g.opts.staticMappings {
+		if m.isIPv6() {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *gateway) acquire(ctx context.Context, vppConn api.Connection) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.refs == 0 {
+		if err := g.enable(ctx, vppConn); err != nil {
+			_ = g.disable(ctx, vppConn)
+			return err
+		}
+	}
+	g.refs++
+	return nil
+}
+
+func (g *gateway) release(ctx context.Context, vppConn api.Connection) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.refs == 0 {
+		return nil
+	}
+	g.refs--
+	if g.refs == 0 {
+		return g.disable(ctx, vppConn)
+	}
+	return nil
+}
+
+func (g *gateway) enable(ctx context.Context, vppConn api.Connection) error {
+	outside, err := interfaceByName(ctx, vppConn, g.opts.outside)
+	if err != nil {
+		return err
+	}
+	g.outside = outside
+
+	if g.nat44() {
+		if !g.nat44Used {
+			if err := nat44Enable(ctx, vppConn); err != nil {
+				return err
+			}
+			g.nat44Used = true
+		}
+		if err := nat44InterfaceAddDel(ctx, vppConn, g.outside, nat_types.NAT_IS_OUTSIDE, true); err != nil {
+			return err
+		}
+		for _, r := range g.opts.pool {
+			if err := nat44AddressRangeAddDel(ctx, vppConn, r, true); err != nil {
+				return err
+			}
+		}
+	}
+	if g.nat66() {
+		if !g.nat66Used {
+			if err := nat66Enable(ctx, vppConn); err != nil {
+				return err
+			}
+			g.nat66Used = true
+		}
+		if err := nat66InterfaceAddDel(ctx, vppConn, g.outside, nat_types.NAT_IS_OUTSIDE, true); err != nil {
+			return err
+		}
+	}
+	for _, m := range g.opts.staticMappings {
+		if err := staticMappingAddDel(ctx, vppConn, m, true); err != nil {
+			return err
+		}
+	}
+	for _, n := range g.opts.npts {
+		if err := nptAddDel(ctx, vppConn, g.outside, n, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *gateway) disable(ctx context.Context, vppConn api.Connection) error {
+	var err error
+	for _, n := range g.opts.npts {
+		if nptErr := nptAddDel(ctx, vppConn, g.outside, n, false); nptErr != nil {
+			err = multierror.Append(err, nptErr)
+		}
+	}
+	for _, m := range g.opts.staticMappings {
+		if mappingErr := staticMappingAddDel(ctx, vppConn, m, false); mappingErr != nil {
+			err = multierror.Append(err, mappingErr)
+		}
+	}
+	if g.nat66() {
+		if ifErr := nat66InterfaceAddDel(ctx, vppConn, g.outside, nat_types.NAT_IS_OUTSIDE, false); ifErr != nil {
+			err = multierror.Append(err, ifErr)
+		}
+	}
+	if g.nat44() {
+		for _, r := range g.opts.pool {
+			if rangeErr := nat44AddressRangeAddDel(ctx, vppConn, r, false); rangeErr != nil {
+				err = multierror.Append(err, rangeErr)
+			}
+		}
+		if ifErr := nat44InterfaceAddDel(ctx, vppConn, g.outside, nat_types.NAT_IS_OUTSIDE, false); ifErr != nil {
+			err = multierror.Append(err, ifErr)
+		}
+	}
+	return err
+}
+
+func interfaceByName(ctx context.Context, vppConn api.Connection, name string) (interface_types.InterfaceIndex, error) {
+	now := time.Now()
+	client, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{
+		SwIfIndex:       ^interface_types.InterfaceIndex(0),
+		NameFilterValid: true,
+		NameFilter:      name,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "vppapi SwInterfaceDump returned error")
+	}
+	defer func() { _ = client.Close() }()
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, errors.Wrap(err, "vppapi SwInterfaceDump returned error")
+		}
+		if details.InterfaceName == name {
+			log.FromContext(ctx).
+				WithField("swIfIndex", details.SwIfIndex).
+				WithField("name", name).
+				WithField("duration", time.Since(now)).
+				WithField("vppapi", "SwInterfaceDump").Debug("completed")
+			return details.SwIfIndex, nil
+		}
+	}
+	return 0, errors.Errorf("no outside interface found %s", name)
+}
+
+func nat44Enable(ctx context.Context, vppConn api.Connection) error {
+	now := time.Now()
+	if _, err := nat44_ed.NewServiceClient(vppConn).Nat44EdPluginEnableDisable(ctx, &nat44_ed.Nat44EdPluginEnableDisable{
+		Enable: true,
+	}); err != nil {
+		return errors.Wrap(err, "vppapi Nat44EdPluginEnableDisable returned error")
+	}
+	log.FromContext(ctx).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "Nat44EdPluginEnableDisable").Debug("completed")
+	return nil
+}
+
+func nat44InterfaceAddDel(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, flags nat_types.NatConfigFlags, isAdd bool) error {
+	now := time.Now()
+	if _, err := nat44_ed.NewServiceClient(vppConn).Nat44InterfaceAddDelFeature(ctx, &nat44_ed.Nat44InterfaceAddDelFeature{
+		IsAdd:     isAdd,
+		Flags:     flags,
+		SwIfIndex: swIfIndex,
+	}); err != nil {
+		return errors.Wrap(err, "vppapi Nat44InterfaceAddDelFeature returned error")
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("flags", flags).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "Nat44InterfaceAddDelFeature").Debug("completed")
+	return nil
+}
+
+func nat44AddressRangeAddDel(ctx context.Context, vppConn api.Connection, r *addressRange, isAdd bool) error {
+	now := time.Now()
+	if _, err := nat44_ed.NewServiceClient(vppConn).Nat44AddDelAddressRange(ctx, &nat44_ed.Nat44AddDelAddressRange{
+		FirstIPAddress: types.ToVppIP4Address(r.first),
+		LastIPAddress:  types.ToVppIP4Address(r.last),
+		VrfID:          ^uint32(0),
+		IsAdd:          isAdd,
+	}); err != nil {
+		return errors.Wrap(err, "vppapi Nat44AddDelAddressRange returned error")
+	}
+	log.FromContext(ctx).
+		WithField("first", r.first).
+		WithField("last", r.last).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "Nat44AddDelAddressRange").Debug("completed")
+	return nil
+}
+
+func nat66Enable(ctx context.Context, vppConn api.Connection) error {
+	now := time.Now()
+	if _, err := nat66.NewServiceClient(vppConn).Nat66PluginEnableDisable(ctx, &nat66.Nat66PluginEnableDisable{
+		Enable: true,
+	}); err != nil {
+		return errors.Wrap(err, "vppapi Nat66PluginEnableDisable returned error")
+	}
+	log.FromContext(ctx).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "Nat66PluginEnableDisable").Debug("completed")
+	return nil
+}
+
+func nat66InterfaceAddDel(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, flags nat_types.NatConfigFlags, isAdd bool) error {
+	now := time.Now()
+	if _, err := nat66.NewServiceClient(vppConn).Nat66AddDelInterface(ctx, &nat66.Nat66AddDelInterface{
+		IsAdd:     isAdd,
+		Flags:     flags,
+		SwIfIndex: swIfIndex,
+	}); err != nil {
+		return errors.Wrap(err, "vppapi Nat66AddDelInterface returned error")
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("flags", flags).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "Nat66AddDelInterface").Debug("completed")
+	return nil
+}
+
+func staticMappingAddDel(ctx context.Context, vppConn api.Connection, m *StaticMapping, isAdd bool) error {
+	now := time.Now()
+	if m.isIPv6() {
+		if _, err := nat66.NewServiceClient(vppConn).Nat66AddDelStaticMapping(ctx, &nat66.Nat66AddDelStaticMapping{
+			IsAdd:             isAdd,
+			LocalIPAddress:    types.ToVppIP6Address(m.LocalIP),
+			ExternalIPAddress: types.ToVppIP6Address(m.ExternalIP),
+		}); err != nil {
+			return errors.Wrap(err, "vppapi Nat66AddDelStaticMapping returned error")
+		}
+		log.FromContext(ctx).
+			WithField("local", m.LocalIP).
+			WithField("external", m.ExternalIP).
+			WithField("isAdd", isAdd).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "Nat66AddDelStaticMapping").Debug("completed")
+		return nil
+	}
+
+	flags := nat_types.NAT_IS_NONE
+	if m.addrOnly() {
+		flags = nat_types.NAT_IS_ADDR_ONLY
+	}
+	if _, err := nat44_ed.NewServiceClient(vppConn).Nat44AddDelStaticMappingV2(ctx, &nat44_ed.Nat44AddDelStaticMappingV2{
+		IsAdd:             isAdd,
+		Flags:             flags,
+		LocalIPAddress:    types.ToVppIP4Address(m.LocalIP),
+		ExternalIPAddress: types.ToVppIP4Address(m.ExternalIP),
+		Protocol:          uint8(m.Protocol),
+		LocalPort:         m.LocalPort,
+		ExternalPort:      m.ExternalPort,
+		ExternalSwIfIndex: ^interface_types.InterfaceIndex(0),
+		Tag:               natTag,
+	}); err != nil {
+		return errors.Wrap(err, "vppapi Nat44AddDelStaticMappingV2 returned error")
+	}
+	log.FromContext(ctx).
+		WithField("local", m.LocalIP).
+		WithField("localPort", m.LocalPort).
+		WithField("external", m.ExternalIP).
+		WithField("externalPort", m.ExternalPort).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "Nat44AddDelStaticMappingV2").Debug("completed")
+	return nil
+}
+
+func nptAddDel(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, n *npt, isAdd bool) error {
+	now := time.Now()
+	if _, err := npt66.NewServiceClient(vppConn).Npt66BindingAddDel(ctx, &npt66.Npt66BindingAddDel{
+		IsAdd:     isAdd,
+		SwIfIndex: swIfIndex,
+		Internal:  ip_types.NewIP6Prefix(*n.internal),
+		External:  ip_types.NewIP6Prefix(*n.external),
+	}); err != nil {
+		return errors.Wrap(err, "vppapi Npt66BindingAddDel returned error")
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("internal", n.internal).
+		WithField("external", n.external).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "Npt66BindingAddDel").Debug("completed")
+	return nil
+}
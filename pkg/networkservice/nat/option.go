@@ -0,0 +1,89 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nat
+
+import (
+	"net"
+
+	"github.com/networkservicemesh/govpp/binapi/ip_types"
+)
+
+// StaticMapping - maps the inside address and port to the outside ones. IPv6 mappings are address only
+type StaticMapping struct {
+	Protocol     ip_types.IPProto
+	LocalIP      net.IP
+	LocalPort    uint16
+	ExternalIP   net.IP
+	ExternalPort uint16
+}
+
+func (m *StaticMapping) isIPv6() bool {
+	return m.LocalIP.To4() == nil
+}
+
+// addrOnly - the mapping has no ports
+func (m *StaticMapping) addrOnly() bool {
+	return m.LocalPort == 0 && m.ExternalPort == 0
+}
+
+type addressRange struct {
+	first net.IP
+	last  net.IP
+}
+
+type npt struct {
+	internal *net.IPNet
+	external *net.IPNet
+}
+
+type options struct {
+	outside        string
+	pool           []*addressRange
+	staticMappings []*StaticMapping
+	npts           []*npt
+}
+
+// Option is an option pattern for nat server
+type Option func(o *options)
+
+// WithOutsideInterface - sets the name of the VPP uplink interface used as NAT outside
+func WithOutsideInterface(name string) Option {
+	return func(o *options) {
+		o.outside = name
+	}
+}
+
+// WithPool - adds the IPv4 address range [first, last] to the NAT44 pool
+func WithPool(first, last net.IP) Option {
+	return func(o *options) {
+		o.pool = append(o.pool, &addressRange{first: first, last: last})
+	}
+}
+
+// WithStaticMapping - adds the static mapping of the inside address and port to the outside ones
+func WithStaticMapping(mapping *StaticMapping) Option {
+	return func(o *options) {
+		o.staticMappings = append(o.staticMappings, mapping)
+	}
+}
+
+// WithNPTv6 - translates the internal IPv6 prefix to the external one on the uplink
+func WithNPTv6(internal, external *net.IPNet) Option {
+	return func(o *options) {
+		o.npts = append(o.npts, &npt{internal: internal, external: external})
+	}
+}
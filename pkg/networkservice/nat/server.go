@@ -0,0 +1,121 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nat
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/vrf"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+type natServer struct {
+	vppConn api.Connection
+	gateway *gateway
+}
+
+// NewServer creates a NetworkServiceServer chain element making the connection interface the NAT inside of the
+// configured outside interface. The number of the NAT44 sessions of the connection is saved in the path segment
+// metrics, the sessions are deleted on Close
+func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &natServer{
+		vppConn: vppConn,
+		gateway: &gateway{opts: o},
+	}
+}
+
+func (n *natServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	swIfIndex, ok := ifindex.Load(ctx, metadata.IsClient(n))
+	if !ok {
+		return conn, nil
+	}
+	if _, ok := load(ctx, metadata.IsClient(n)); !ok {
+		if err := n.gateway.acquire(ctx, n.vppConn); err != nil {
+			closeCtx, cancelClose := postponeCtxFunc()
+			defer cancelClose()
+
+			if _, closeErr := n.Close(closeCtx, conn); closeErr != nil {
+				err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+			}
+			return nil, err
+		}
+		store(ctx, metadata.IsClient(n), swIfIndex)
+
+		if err := insideAddDel(ctx, n.vppConn, n.gateway, swIfIndex, true); err != nil {
+			closeCtx, cancelClose := postponeCtxFunc()
+			defer cancelClose()
+
+			if _, closeErr := n.Close(closeCtx, conn); closeErr != nil {
+				err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+			}
+			return nil, err
+		}
+	}
+
+	if n.gateway.nat44() {
+		vrfID, _ := vrf.Load(ctx, metadata.IsClient(n), false)
+		if sessions, err := countSessions(ctx, n.vppConn, insideIPs(conn), vrfID); err == nil {
+			fillSessions(conn, sessions)
+		} else {
+			log.FromContext(ctx).WithField("nat", "server").Warnf("failed to count the sessions: %s", err.Error())
+		}
+	}
+	return conn, nil
+}
+
+func (n *natServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if swIfIndex, ok := loadAndDelete(ctx, metadata.IsClient(n)); ok {
+		logger := log.FromContext(ctx).WithField("nat", "server")
+		if n.gateway.nat44() {
+			vrfID, _ := vrf.Load(ctx, metadata.IsClient(n), false)
+			for _, ip := range insideIPs(conn) {
+				if err := delSessions(ctx, n.vppConn, ip, vrfID); err != nil {
+					logger.Errorf("failed to delete the sessions: %s", err.Error())
+				}
+			}
+		}
+		if err := insideAddDel(ctx, n.vppConn, n.gateway, swIfIndex, false); err != nil {
+			logger.Errorf("failed to delete the inside interface: %s", err.Error())
+		}
+		if err := n.gateway.release(ctx, n.vppConn); err != nil {
+			logger.Errorf("failed to delete the gateway: %s", err.Error())
+		}
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rxmode
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type rxModeClient struct {
+	chainCtx context.Context
+	vppConn  api.Connection
+	opts     *rxModeOptions
+}
+
+// NewClient - returns a new client chain element setting the rx mode of the connection interface
+func NewClient(chainCtx context.Context, vppConn api.Connection, options ...Option) networkservice.NetworkServiceClient {
+	opts := &rxModeOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+	return &rxModeClient{
+		chainCtx: chainCtx,
+		vppConn:  vppConn,
+		opts:     opts,
+	}
+}
+
+func (r *rxModeClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	mode, ok := r.opts.mode(ctx, request.GetConnection())
+	if ok {
+		storeMode(ctx, metadata.IsClient(r), mode)
+	} else {
+		deleteMode(ctx, metadata.IsClient(r))
+	}
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apply(ctx, r.chainCtx, r.vppConn, mode, ok, metadata.IsClient(r)); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := r.Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (r *rxModeClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if w, ok := loadAndDelete(ctx, metadata.IsClient(r)); ok {
+		w.cancel()
+	}
+	deleteMode(ctx, metadata.IsClient(r))
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
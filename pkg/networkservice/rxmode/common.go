@@ -0,0 +1,161 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rxmode
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	interfaces "github.com/networkservicemesh/govpp/binapi/interface"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+// mode - returns the rx mode of the connection interface. The ok result reports whether any rx mode is selected.
+func (o *rxModeOptions) mode(ctx context.Context, conn *networkservice.Connection) (interface_types.RxMode, bool) {
+	if name, ok := conn.GetLabels()[LabelKey]; ok {
+		mode, err := ParseMode(name)
+		if err == nil {
+			return mode, true
+		}
+		log.FromContext(ctx).Warnf("invalid %s label: %v", LabelKey, err)
+	}
+	if mode, ok := o.networkServiceToRxMode[conn.GetNetworkService()]; ok {
+		return mode, true
+	}
+	return o.defaultMode, o.defaultMode != interface_types.RX_MODE_API_UNKNOWN
+}
+
+// apply - sets the rx mode of the connection interface and keeps it set until chainCtx is done or the connection is closed.
+// If no rx mode is selected, the rx mode of the interface is left as is.
+func apply(ctx, chainCtx context.Context, vppConn api.Connection, mode interface_types.RxMode, selected, isClient bool) error {
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	if w, ok := load(ctx, isClient); ok {
+		if selected && w.swIfIndex == swIfIndex && w.mode == mode {
+			return nil
+		}
+		w.cancel()
+		deleteWatcher(ctx, isClient)
+	}
+	if !selected {
+		return nil
+	}
+
+	watchCtx, cancel := context.WithCancel(chainCtx)
+	watchCtx = log.WithLog(watchCtx, log.FromContext(ctx))
+	if err := watch(watchCtx, vppConn, swIfIndex, mode); err != nil {
+		cancel()
+		return err
+	}
+	store(ctx, isClient, &watcher{
+		swIfIndex: swIfIndex,
+		mode:      mode,
+		cancel:    cancel,
+	})
+	return nil
+}
+
+// watch - sets the rx mode now and every time the link of the interface goes up: the queues of some
+// interfaces (e.g. memif) exist only while they are connected
+func watch(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, mode interface_types.RxMode) error {
+	watcher, err := vppConn.WatchEvent(ctx, &interfaces.SwInterfaceEvent{})
+	if err != nil {
+		return errors.Wrap(err, "failed to watch interfaces.SwInterfaceEvent")
+	}
+	if err := setRxMode(ctx, vppConn, swIfIndex, mode); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case rawMsg := <-watcher.Events():
+				if msg, ok := rawMsg.(*interfaces.SwInterfaceEvent); ok &&
+					msg.SwIfIndex == swIfIndex &&
+					msg.Flags&interface_types.IF_STATUS_API_FLAG_LINK_UP != 0 {
+					if err := setRxMode(ctx, vppConn, swIfIndex, mode); err != nil {
+						log.FromContext(ctx).WithField("swIfIndex", swIfIndex).Errorf("failed to set rx mode: %v", err)
+					}
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// setRxMode - sets the rx mode of every rx queue of the interface
+func setRxMode(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, mode interface_types.RxMode) error {
+	now := time.Now()
+	client, err := interfaces.NewServiceClient(vppConn).SwInterfaceRxPlacementDump(ctx, &interfaces.SwInterfaceRxPlacementDump{
+		SwIfIndex: swIfIndex,
+	})
+	if err != nil {
+		return errors.Wrap(err, "vppapi SwInterfaceRxPlacementDump returned error")
+	}
+	defer func() { _ = client.Close() }()
+
+	var queueIDs []uint32
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "vppapi SwInterfaceRxPlacementDump returned error")
+		}
+		if details.SwIfIndex == swIfIndex && details.Mode != mode {
+			queueIDs = append(queueIDs, details.QueueID)
+		}
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceRxPlacementDump").Debug("completed")
+
+	for _, queueID := range queueIDs {
+		now = time.Now()
+		if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetRxMode(ctx, &interfaces.SwInterfaceSetRxMode{
+			SwIfIndex:    swIfIndex,
+			QueueIDValid: true,
+			QueueID:      queueID,
+			Mode:         mode,
+		}); err != nil {
+			return errors.Wrapf(err, "vppapi SwInterfaceSetRxMode returned error for queue %d", queueID)
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", swIfIndex).
+			WithField("queueID", queueID).
+			WithField("mode", mode).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "SwInterfaceSetRxMode").Debug("completed")
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rxmode
+
+import (
+	"strings"
+
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// LabelKey - connection label selecting the rx mode of the connection interfaces: polling, interrupt or adaptive
+	LabelKey = "rxmode"
+)
+
+// ParseMode - parses the rx mode name: polling, interrupt or adaptive
+func ParseMode(name string) (interface_types.RxMode, error) {
+	switch strings.ToLower(name) {
+	case "polling":
+		return interface_types.RX_MODE_API_POLLING, nil
+	case "interrupt":
+		return interface_types.RX_MODE_API_INTERRUPT, nil
+	case "adaptive":
+		return interface_types.RX_MODE_API_ADAPTIVE, nil
+	default:
+		return interface_types.RX_MODE_API_UNKNOWN, errors.Errorf("unknown rx mode %q: expected polling, interrupt or adaptive", name)
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rxmode provides networkservice.NetworkService{Client,Server} chain elements setting the rx mode
+// (polling, interrupt or adaptive) of every rx queue of the connection interface.
+// The mode is chosen by the connection label, then by the network service, then the default one is used.
+// If no mode is selected, the rx mode set by the mechanism (adaptive for memif and kernel tap) is kept.
+// The chain elements must be placed before the mechanism chain elements, so that the mechanisms know the
+// rx mode is selected and the interface already exists when the chain elements get the connection back.
+package rxmode
@@ -0,0 +1,89 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rxmode
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type key struct{}
+type modeKey struct{}
+
+// watcher - keeps the rx mode of the interface set
+type watcher struct {
+	swIfIndex interface_types.InterfaceIndex
+	mode      interface_types.RxMode
+	cancel    context.CancelFunc
+}
+
+// store sets the watcher stored in per Connection.Id metadata.
+func store(ctx context.Context, isClient bool, w *watcher) {
+	metadata.Map(ctx, isClient).Store(key{}, w)
+}
+
+// deleteWatcher deletes the watcher stored in per Connection.Id metadata
+func deleteWatcher(ctx context.Context, isClient bool) {
+	metadata.Map(ctx, isClient).Delete(key{})
+}
+
+// load returns the watcher stored in per Connection.Id metadata.
+// The ok result indicates whether value was found in the per Connection.Id metadata.
+func load(ctx context.Context, isClient bool) (value *watcher, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*watcher)
+	return value, ok
+}
+
+// loadAndDelete deletes the watcher stored in per Connection.Id metadata,
+// returning the previous value if any. The loaded result reports whether the key was present.
+func loadAndDelete(ctx context.Context, isClient bool) (value *watcher, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*watcher)
+	return value, ok
+}
+
+// storeMode sets the rx mode selected for the connection interface in per Connection.Id metadata.
+func storeMode(ctx context.Context, isClient bool, mode interface_types.RxMode) {
+	metadata.Map(ctx, isClient).Store(modeKey{}, mode)
+}
+
+// deleteMode deletes the rx mode selected for the connection interface from per Connection.Id metadata.
+func deleteMode(ctx context.Context, isClient bool) {
+	metadata.Map(ctx, isClient).Delete(modeKey{})
+}
+
+// LoadMode returns the rx mode selected for the connection interface by the rxmode chain element.
+// The ok result indicates whether any rx mode is selected, mechanisms setting their own default rx mode
+// should skip it in this case.
+func LoadMode(ctx context.Context, isClient bool) (value interface_types.RxMode, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(modeKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(interface_types.RxMode)
+	return value, ok
+}
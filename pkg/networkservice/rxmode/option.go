@@ -0,0 +1,44 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rxmode
+
+import (
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+)
+
+type rxModeOptions struct {
+	defaultMode            interface_types.RxMode
+	networkServiceToRxMode map[string]interface_types.RxMode
+}
+
+// Option is an option pattern for rxmode chain elements
+type Option func(o *rxModeOptions)
+
+// WithDefaultMode - sets the rx mode used when neither the connection label nor the network service selects one.
+// Default: none - the rx mode set by the mechanism is kept (adaptive for memif and kernel tap interfaces)
+func WithDefaultMode(mode interface_types.RxMode) Option {
+	return func(o *rxModeOptions) {
+		o.defaultMode = mode
+	}
+}
+
+// WithNetworkServiceModes - sets the rx modes of the network services
+func WithNetworkServiceModes(networkServiceToRxMode map[string]interface_types.RxMode) Option {
+	return func(o *rxModeOptions) {
+		o.networkServiceToRxMode = networkServiceToRxMode
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package rxplacement
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	interfaces "github.com/networkservicemesh/govpp/binapi/interface"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+// pinnedWorker - returns the worker the connection is pinned to by the label or nil
+func pinnedWorker(conn *networkservice.Connection) (*uint32, error) {
+	value, ok := conn.GetLabels()[LabelKey]
+	if !ok {
+		return nil, nil
+	}
+	worker, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s label", LabelKey)
+	}
+	rv := uint32(worker)
+	return &rv, nil
+}
+
+// apply - places the rx queues of the connection interface and keeps them placed until chainCtx is done
+// or the connection is closed
+func (p *Placer) apply(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	if err := p.init(ctx); err != nil {
+		return err
+	}
+	pinned, err := pinnedWorker(conn)
+	if err != nil {
+		return err
+	}
+	pinKey := ""
+	if pinned != nil {
+		pinKey = strconv.FormatUint(uint64(*pinned), 10)
+	}
+
+	if w, ok := load(ctx, isClient); ok {
+		if w.swIfIndex == swIfIndex && w.pinKey == pinKey {
+			return nil
+		}
+		w.cancel()
+		deleteWatcher(ctx, isClient)
+		if w.swIfIndex != swIfIndex {
+			p.remove(w.swIfIndex)
+		}
+	}
+
+	watchCtx, cancel := context.WithCancel(p.chainCtx)
+	watchCtx = log.WithLog(watchCtx, log.FromContext(ctx))
+	if err := p.watch(watchCtx, swIfIndex, pinned); err != nil {
+		cancel()
+		return err
+	}
+	store(ctx, isClient, &watcher{
+		swIfIndex: swIfIndex,
+		pinKey:    pinKey,
+		cancel:    cancel,
+	})
+	return nil
+}
+
+// watch - places the rx queues now and every time the link of the interface goes up: the queues of some
+// interfaces (e.g. memif) exist only while they are connected
+func (p *Placer) watch(ctx context.Context, swIfIndex interface_types.InterfaceIndex, pinned *uint32) error {
+	watcher, err := p.vppConn.WatchEvent(ctx, &interfaces.SwInterfaceEvent{})
+	if err != nil {
+		return errors.Wrap(err, "failed to watch interfaces.SwInterfaceEvent")
+	}
+	if err := p.place(ctx, swIfIndex, pinned); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case rawMsg := <-watcher.Events():
+				if msg, ok := rawMsg.(*interfaces.SwInterfaceEvent); ok &&
+					msg.SwIfIndex == swIfIndex &&
+					msg.Flags&interface_types.IF_STATUS_API_FLAG_LINK_UP != 0 {
+					if err := p.place(ctx, swIfIndex, pinned); err != nil {
+						log.FromContext(ctx).WithField("swIfIndex", swIfIndex).Errorf("failed to place rx queues: %v", err)
+					}
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// release - stops keeping the rx queues of the connection interface placed
+func (p *Placer) release(ctx context.Context, isClient bool) {
+	if w, ok := loadAndDelete(ctx, isClient); ok {
+		w.cancel()
+		p.remove(w.swIfIndex)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rxplacement
+
+import "time"
+
+// Strategy - the way the worker is chosen for the rx queue not pinned by the label
+type Strategy int
+
+const (
+	// RoundRobin - the workers are used in turn
+	RoundRobin Strategy = iota
+	// LeastLoaded - the worker with the lowest vector rate is used, ties are broken by the number of the queues
+	LeastLoaded
+)
+
+const (
+	// LabelKey - connection label pinning the rx queues of the connection interfaces to the worker with the given index
+	LabelKey = "rxplacement-worker"
+
+	defaultRebalanceInterval = 30 * time.Second
+
+	// skewRatio and minSkew - the load is skewed if the vector rate of the most loaded worker is skewRatio times
+	// and at least minSkew higher than the one of the least loaded worker
+	skewRatio = 2
+	minSkew   = 8
+)
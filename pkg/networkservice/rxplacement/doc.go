@@ -0,0 +1,24 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rxplacement provides networkservice.NetworkService{Client,Server} chain elements assigning the rx queues
+// of the connection interfaces to VPP worker threads.
+// The worker is chosen by the connection label (pinning), otherwise by the strategy: round-robin or least-loaded
+// (by per-worker vector rates from the stats segment). The queues not pinned by the label are moved from the most
+// loaded worker to the least loaded one in the background when the load is skewed.
+// The chain elements must be placed before the mechanism chain elements, so that the interface already
+// exists when they get the connection back.
+package rxplacement
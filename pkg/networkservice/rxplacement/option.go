@@ -0,0 +1,64 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package rxplacement
+
+import "time"
+
+type rxPlacementOptions struct {
+	strategy          Strategy
+	statsSocket       string
+	rebalanceInterval time.Duration
+	placer            *Placer
+}
+
+// Option is an option pattern for rxplacement chain elements
+type Option func(o *rxPlacementOptions)
+
+// WithStrategy - sets the strategy of choosing the worker for the rx queue not pinned by the label.
+// Default: RoundRobin
+func WithStrategy(strategy Strategy) Option {
+	return func(o *rxPlacementOptions) {
+		o.strategy = strategy
+	}
+}
+
+// WithStatsSocket - sets the VPP stats socket the per-worker vector rates are read from.
+// Default: adapter.DefaultStatsSocket
+func WithStatsSocket(socket string) Option {
+	return func(o *rxPlacementOptions) {
+		o.statsSocket = socket
+	}
+}
+
+// WithRebalanceInterval - sets the interval of checking the workers load to rebalance the rx queues. 0 disables rebalancing.
+// Default: 30s
+func WithRebalanceInterval(interval time.Duration) Option {
+	return func(o *rxPlacementOptions) {
+		o.rebalanceInterval = interval
+	}
+}
+
+// WithSharedPlacer - sets the Placer shared by the client and server chain elements, so that a single rebalancer
+// moves the rx queues of the VPP workers. The other options are taken from the Placer if it is set
+func WithSharedPlacer(placer *Placer) Option {
+	return func(o *rxPlacementOptions) {
+		o.placer = placer
+	}
+}
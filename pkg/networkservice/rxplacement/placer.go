@@ -0,0 +1,347 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package rxplacement
+
+import (
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/adapter"
+	"go.fd.io/govpp/adapter/statsclient"
+	"go.fd.io/govpp/api"
+	"go.fd.io/govpp/core"
+
+	interfaces "github.com/networkservicemesh/govpp/binapi/interface"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/govpp/binapi/vlib"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// rxQueue - rx queue of the interface
+type rxQueue struct {
+	swIfIndex interface_types.InterfaceIndex
+	queueID   uint32
+}
+
+// placement - worker the rx queue is assigned to
+type placement struct {
+	worker uint32
+	pinned bool
+}
+
+// Placer - assigns the rx queues to the workers and keeps the assignments balanced
+type Placer struct {
+	chainCtx context.Context
+	vppConn  api.Connection
+	opts     *rxPlacementOptions
+
+	initOnce   sync.Once
+	initErr    error
+	numWorkers uint32
+	statsConn  *core.StatsConnection
+
+	mu     sync.Mutex
+	next   uint32
+	queues map[rxQueue]placement
+}
+
+// NewPlacer - returns a new Placer of the rx queues of the VPP workers. A single Placer must be shared by all the
+// rxplacement chain elements of the VPP (see WithSharedPlacer), otherwise their rebalancers move the same queues.
+func NewPlacer(chainCtx context.Context, vppConn api.Connection, options ...Option) *Placer {
+	opts := &rxPlacementOptions{
+		strategy:          RoundRobin,
+		rebalanceInterval: defaultRebalanceInterval,
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+	return &Placer{
+		chainCtx: chainCtx,
+		vppConn:  vppConn,
+		opts:     opts,
+		queues:   make(map[rxQueue]placement),
+	}
+}
+
+// sharedPlacer - returns the Placer set by WithSharedPlacer or a new one
+func sharedPlacer(chainCtx context.Context, vppConn api.Connection, options ...Option) *Placer {
+	opts := new(rxPlacementOptions)
+	for _, opt := range options {
+		opt(opts)
+	}
+	if opts.placer != nil {
+		return opts.placer
+	}
+	return NewPlacer(chainCtx, vppConn, options...)
+}
+
+func (p *Placer) init(ctx context.Context) error {
+	p.initOnce.Do(func() {
+		now := time.Now()
+		reply, err := vlib.NewServiceClient(p.vppConn).ShowThreads(ctx, &vlib.ShowThreads{})
+		if err != nil {
+			p.initErr = errors.Wrap(err, "vppapi ShowThreads returned error")
+			return
+		}
+		log.FromContext(ctx).
+			WithField("count", reply.Count).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "ShowThreads").Debug("completed")
+		// All the threads but the main one are workers
+		if len(reply.ThreadData) > 1 {
+			p.numWorkers = uint32(len(reply.ThreadData) - 1)
+		}
+		if p.numWorkers < 2 || (p.opts.strategy != LeastLoaded && p.opts.rebalanceInterval == 0) {
+			return
+		}
+
+		statsSocket := p.opts.statsSocket
+		if statsSocket == "" {
+			statsSocket = adapter.DefaultStatsSocket
+		}
+		statsConn, err := core.ConnectStats(statsclient.NewStatsClient(statsSocket))
+		if err != nil {
+			// The queues are still placed, just without the load taken into account
+			log.FromContext(ctx).Errorf("failed to connect to Stats API: %v", err)
+			return
+		}
+		p.statsConn = statsConn
+		go func() {
+			<-p.chainCtx.Done()
+			statsConn.Disconnect()
+		}()
+		if p.opts.rebalanceInterval > 0 {
+			go p.rebalance(log.WithLog(p.chainCtx, log.FromContext(ctx)))
+		}
+	})
+	return p.initErr
+}
+
+// place - assigns the rx queues of the interface to the workers. The queues already assigned keep their workers
+// unless pinnedWorker is set
+func (p *Placer) place(ctx context.Context, swIfIndex interface_types.InterfaceIndex, pinnedWorker *uint32) error {
+	if p.numWorkers < 2 {
+		return nil
+	}
+	if pinnedWorker != nil && *pinnedWorker >= p.numWorkers {
+		return errors.Errorf("invalid %s label: worker %d doesn't exist, there are %d workers", LabelKey, *pinnedWorker, p.numWorkers)
+	}
+
+	queues, err := dumpRxPlacement(ctx, p.vppConn, swIfIndex)
+	if err != nil {
+		return err
+	}
+	loads := p.loads(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, details := range queues {
+		key := rxQueue{swIfIndex: swIfIndex, queueID: details.QueueID}
+		pl, ok := p.queues[key]
+		switch {
+		case pinnedWorker != nil:
+			pl = placement{worker: *pinnedWorker, pinned: true}
+		case !ok || pl.pinned:
+			pl = placement{worker: p.choose(loads)}
+		}
+		if details.WorkerID != threadIndex(pl.worker) {
+			if err := setRxPlacement(ctx, p.vppConn, key, pl.worker); err != nil {
+				return err
+			}
+		}
+		p.queues[key] = pl
+	}
+	return nil
+}
+
+// remove - forgets the rx queues of the interface
+func (p *Placer) remove(swIfIndex interface_types.InterfaceIndex) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key := range p.queues {
+		if key.swIfIndex == swIfIndex {
+			delete(p.queues, key)
+		}
+	}
+}
+
+// choose - returns the worker for the new rx queue
+func (p *Placer) choose(loads []float64) uint32 {
+	if p.opts.strategy == LeastLoaded && loads != nil {
+		counts := make([]int, p.numWorkers)
+		for _, pl := range p.queues {
+			counts[pl.worker]++
+		}
+		var rv uint32
+		for worker := uint32(1); worker < p.numWorkers; worker++ {
+			if loads[worker] < loads[rv] || (loads[worker] == loads[rv] && counts[worker] < counts[rv]) {
+				rv = worker
+			}
+		}
+		return rv
+	}
+	rv := p.next % p.numWorkers
+	p.next++
+	return rv
+}
+
+// loads - returns the vector rates of the workers or nil if they are unknown
+func (p *Placer) loads(ctx context.Context) []float64 {
+	if p.statsConn == nil {
+		return nil
+	}
+	stats := new(api.SystemStats)
+	if err := p.statsConn.GetSystemStats(stats); err != nil {
+		log.FromContext(ctx).Errorf("getting system stats failed: %v", err)
+		return nil
+	}
+	rates := stats.VectorRatePerWorker
+	// The rates may include the main thread
+	if uint32(len(rates)) == p.numWorkers+1 {
+		rates = rates[1:]
+	}
+	if uint32(len(rates)) != p.numWorkers {
+		return nil
+	}
+	rv := make([]float64, p.numWorkers)
+	for i, rate := range rates {
+		rv[i] = float64(rate)
+	}
+	return rv
+}
+
+// rebalance - moves one rx queue not pinned by the label from the most loaded worker to the least loaded one
+// every rebalance interval while the load is skewed
+func (p *Placer) rebalance(ctx context.Context) {
+	ticker := time.NewTicker(p.opts.rebalanceInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		loads := p.loads(ctx)
+		if loads == nil {
+			continue
+		}
+		var hot, cold uint32
+		for worker := range loads {
+			if loads[worker] > loads[hot] {
+				hot = uint32(worker)
+			}
+			if loads[worker] < loads[cold] {
+				cold = uint32(worker)
+			}
+		}
+		if loads[hot] < skewRatio*loads[cold] || loads[hot]-loads[cold] < minSkew {
+			continue
+		}
+		p.move(ctx, hot, cold)
+	}
+}
+
+func (p *Placer) move(ctx context.Context, from, to uint32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var candidates []rxQueue
+	for key, pl := range p.queues {
+		if pl.worker == from && !pl.pinned {
+			candidates = append(candidates, key)
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+	// The most recently created interface is moved
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].swIfIndex != candidates[j].swIfIndex {
+			return candidates[i].swIfIndex > candidates[j].swIfIndex
+		}
+		return candidates[i].queueID < candidates[j].queueID
+	})
+	if err := setRxPlacement(ctx, p.vppConn, candidates[0], to); err != nil {
+		log.FromContext(ctx).Errorf("failed to rebalance rx queue: %v", err)
+		return
+	}
+	p.queues[candidates[0]] = placement{worker: to}
+}
+
+// threadIndex - returns VPP thread index of the worker. Thread 0 is the main one
+func threadIndex(worker uint32) uint32 {
+	return worker + 1
+}
+
+func dumpRxPlacement(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) ([]*interfaces.SwInterfaceRxPlacementDetails, error) {
+	now := time.Now()
+	client, err := interfaces.NewServiceClient(vppConn).SwInterfaceRxPlacementDump(ctx, &interfaces.SwInterfaceRxPlacementDump{
+		SwIfIndex: swIfIndex,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "vppapi SwInterfaceRxPlacementDump returned error")
+	}
+	defer func() { _ = client.Close() }()
+
+	var rv []*interfaces.SwInterfaceRxPlacementDetails
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "vppapi SwInterfaceRxPlacementDump returned error")
+		}
+		if details.SwIfIndex == swIfIndex {
+			rv = append(rv, details)
+		}
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("queues", len(rv)).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceRxPlacementDump").Debug("completed")
+	return rv, nil
+}
+
+func setRxPlacement(ctx context.Context, vppConn api.Connection, queue rxQueue, worker uint32) error {
+	now := time.Now()
+	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetRxPlacement(ctx, &interfaces.SwInterfaceSetRxPlacement{
+		SwIfIndex: queue.swIfIndex,
+		QueueID:   queue.queueID,
+		WorkerID:  worker,
+	}); err != nil {
+		return errors.Wrapf(err, "vppapi SwInterfaceSetRxPlacement returned error for queue %d", queue.queueID)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", queue.swIfIndex).
+		WithField("queueID", queue.queueID).
+		WithField("workerID", worker).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetRxPlacement").Debug("completed")
+	return nil
+}
@@ -0,0 +1,261 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twicenat
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/govpp/binapi/pnat"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/ippool"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+type addressPool struct {
+	pool *ippool.IPPool
+	mu   sync.Mutex
+}
+
+func (p *addressPool) pull() (net.IP, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.pool.Pull()
+}
+
+func (p *addressPool) release(ips ...net.IP) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, ip := range ips {
+		p.pool.Add(ip)
+	}
+}
+
+type binding struct {
+	index      uint32
+	attachment pnat.PnatAttachmentPoint
+}
+
+// mapping - the client addresses and the unique addresses they are translated to
+type mapping struct {
+	// original - client address -> unique address, both in the IP context format
+	original map[string]string
+	// unique - unique address -> client address
+	unique map[string]string
+	// assigned - the addresses assigned by the endpoint, they are unique for it and are never translated
+	assigned  map[string]struct{}
+	swIfIndex interface_types.InterfaceIndex
+	bindings  []*binding
+}
+
+// newMapping - allocates the unique addresses for the IPv4 client addresses not assigned by the endpoint
+func newMapping(ctx context.Context, p *addressPool, srcIPAddrs []string, assigned map[string]struct{}) (*mapping, error) {
+	m := &mapping{
+		original: make(map[string]string),
+		unique:   make(map[string]string),
+		assigned: assigned,
+	}
+	for _, addr := range srcIPAddrs {
+		if _, ok := m.assigned[addr]; ok {
+			continue
+		}
+		ip, _, err := net.ParseCIDR(addr)
+		if err != nil {
+			continue
+		}
+		if ip.To4() == nil {
+			log.FromContext(ctx).WithField("twicenat", "server").Warnf("IPv6 client address %s is not translated", addr)
+			continue
+		}
+		uniqueIP, err := p.pull()
+		if err != nil {
+			m.release(p)
+			return nil, errors.Wrap(err, "failed to allocate the unique address")
+		}
+		uniqueAddr := (&net.IPNet{IP: uniqueIP.To4(), Mask: net.CIDRMask(32, 32)}).String()
+		m.original[addr] = uniqueAddr
+		m.unique[uniqueAddr] = addr
+	}
+	return m, nil
+}
+
+// matches - returns true if the mapping translates exactly the IPv4 client addresses not assigned by the endpoint
+func (m *mapping) matches(srcIPAddrs []string) bool {
+	count := 0
+	for _, addr := range srcIPAddrs {
+		if _, ok := m.assigned[addr]; ok {
+			continue
+		}
+		if ip, _, err := net.ParseCIDR(addr); err != nil || ip.To4() == nil {
+			continue
+		}
+		if _, ok := m.original[addr]; !ok {
+			return false
+		}
+		count++
+	}
+	return count == len(m.original)
+}
+
+// assign - saves the client addresses of the response not translated by the mapping as assigned by the endpoint
+func (m *mapping) assign(srcIPAddrs []string) {
+	m.assigned = make(map[string]struct{})
+	for _, addr := range srcIPAddrs {
+		if _, ok := m.original[addr]; !ok {
+			m.assigned[addr] = struct{}{}
+		}
+	}
+}
+
+func (m *mapping) release(p *addressPool) {
+	for uniqueAddr := range m.unique {
+		if ip, _, err := net.ParseCIDR(uniqueAddr); err == nil {
+			p.release(ip)
+		}
+	}
+}
+
+// translate - replaces the source addresses and the routes to them in the IP context
+func translate(ipContext *networkservice.IPContext, addrs map[string]string) {
+	if ipContext == nil {
+		return
+	}
+	srcIPAddrs := make([]string, len(ipContext.GetSrcIpAddrs()))
+	for i, addr := range ipContext.GetSrcIpAddrs() {
+		srcIPAddrs[i] = addr
+		if translated, ok := addrs[addr]; ok {
+			srcIPAddrs[i] = translated
+		}
+	}
+	ipContext.SrcIpAddrs = srcIPAddrs
+
+	for _, route := range ipContext.GetSrcRoutes() {
+		if translated, ok := addrs[route.GetPrefix()]; ok {
+			route.Prefix = translated
+		}
+	}
+}
+
+// bind - translates the client addresses to the unique ones on the output of the interface and back on its input
+func bind(ctx context.Context, vppConn api.Connection, m *mapping, swIfIndex interface_types.InterfaceIndex) error {
+	m.swIfIndex = swIfIndex
+	for originalAddr, uniqueAddr := range m.original {
+		originalIP, _, _ := net.ParseCIDR(originalAddr)
+		uniqueIP, _, _ := net.ParseCIDR(uniqueAddr)
+
+		if err := bindingAdd(ctx, vppConn, m, pnat.PNAT_IP4_OUTPUT,
+			pnat.PnatMatchTuple{Src: types.ToVppIP4Address(originalIP), Mask: pnat.PNAT_SA},
+			pnat.PnatRewriteTuple{Src: types.ToVppIP4Address(uniqueIP), Mask: pnat.PNAT_SA},
+		); err != nil {
+			return err
+		}
+		if err := bindingAdd(ctx, vppConn, m, pnat.PNAT_IP4_INPUT,
+			pnat.PnatMatchTuple{Dst: types.ToVppIP4Address(uniqueIP), Mask: pnat.PNAT_DA},
+			pnat.PnatRewriteTuple{Dst: types.ToVppIP4Address(originalIP), Mask: pnat.PNAT_DA},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func bindingAdd(ctx context.Context, vppConn api.Connection, m *mapping, attachment pnat.PnatAttachmentPoint, match pnat.PnatMatchTuple, rewrite pnat.PnatRewriteTuple) error {
+	now := time.Now()
+	reply, err := pnat.NewServiceClient(vppConn).PnatBindingAddV2(ctx, &pnat.PnatBindingAddV2{
+		Match:   match,
+		Rewrite: rewrite,
+	})
+	if err != nil {
+		return errors.Wrap(err, "vppapi PnatBindingAddV2 returned error")
+	}
+	log.FromContext(ctx).
+		WithField("bindingIndex", reply.BindingIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "PnatBindingAddV2").Debug("completed")
+
+	b := &binding{
+		index:      reply.BindingIndex,
+		attachment: attachment,
+	}
+	now = time.Now()
+	if _, err := pnat.NewServiceClient(vppConn).PnatBindingAttach(ctx, &pnat.PnatBindingAttach{
+		SwIfIndex:    m.swIfIndex,
+		Attachment:   b.attachment,
+		BindingIndex: b.index,
+	}); err != nil {
+		_ = bindingDel(ctx, vppConn, b)
+		return errors.Wrap(err, "vppapi PnatBindingAttach returned error")
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", m.swIfIndex).
+		WithField("attachment", b.attachment).
+		WithField("bindingIndex", b.index).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "PnatBindingAttach").Debug("completed")
+	m.bindings = append(m.bindings, b)
+	return nil
+}
+
+func unbind(ctx context.Context, vppConn api.Connection, m *mapping) error {
+	var err error
+	for _, b := range m.bindings {
+		now := time.Now()
+		if _, detachErr := pnat.NewServiceClient(vppConn).PnatBindingDetach(ctx, &pnat.PnatBindingDetach{
+			SwIfIndex:    m.swIfIndex,
+			Attachment:   b.attachment,
+			BindingIndex: b.index,
+		}); detachErr != nil {
+			err = multierror.Append(err, errors.Wrap(detachErr, "vppapi PnatBindingDetach returned error"))
+		} else {
+			log.FromContext(ctx).
+				WithField("swIfIndex", m.swIfIndex).
+				WithField("attachment", b.attachment).
+				WithField("bindingIndex", b.index).
+				WithField("duration", time.Since(now)).
+				WithField("vppapi", "PnatBindingDetach").Debug("completed")
+		}
+		if delErr := bindingDel(ctx, vppConn, b); delErr != nil {
+			err = multierror.Append(err, delErr)
+		}
+	}
+	m.bindings = nil
+	return err
+}
+
+func bindingDel(ctx context.Context, vppConn api.Connection, b *binding) error {
+	now := time.Now()
+	if _, err := pnat.NewServiceClient(vppConn).PnatBindingDel(ctx, &pnat.PnatBindingDel{
+		BindingIndex: b.index,
+	}); err != nil {
+		return errors.Wrap(err, "vppapi PnatBindingDel returned error")
+	}
+	log.FromContext(ctx).
+		WithField("bindingIndex", b.index).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "PnatBindingDel").Debug("completed")
+	return nil
+}
@@ -0,0 +1,22 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package twicenat provides chain element letting the clients of the network services with overlapping address
+// spaces reach a shared endpoint: the IPv4 source addresses assigned by the client are replaced by the unique addresses
+// allocated from the forwarder pool in the IP context sent onward, and the packets are translated 1:1 between them
+// on the endpoint side interface of the connection by the VPP PNAT bindings. The addresses allocated by the endpoint
+// IPAM are not translated, the IPv6 addresses are not translated either
+package twicenat
@@ -0,0 +1,47 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twicenat
+
+import (
+	"net"
+)
+
+type options struct {
+	pool            *net.IPNet
+	networkServices map[string]struct{}
+}
+
+// Option is an option pattern for twicenat server
+type Option func(o *options)
+
+// WithPool - sets the IPv4 network the unique client addresses are allocated from. The connections are not translated
+// without the pool
+func WithPool(pool *net.IPNet) Option {
+	return func(o *options) {
+		o.pool = pool
+	}
+}
+
+// WithNetworkServices - translates only the connections to the network services. All the connections are translated
+// by default
+func WithNetworkServices(networkServices ...string) Option {
+	return func(o *options) {
+		for _, networkService := range networkServices {
+			o.networkServices[networkService] = struct{}{}
+		}
+	}
+}
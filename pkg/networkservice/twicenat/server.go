@@ -0,0 +1,145 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twicenat
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/ippool"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+type twicenatServer struct {
+	vppConn         api.Connection
+	pool            *addressPool
+	networkServices map[string]struct{}
+}
+
+// NewServer creates a NetworkServiceServer chain element translating the IPv4 client addresses of the connection to
+// the unique addresses allocated from the pool. Only the addresses assigned by the client are translated: the addresses
+// allocated by the endpoint IPAM are unique for the endpoint and are passed as is. It should be placed after the xconnect
+// so that the xconnect sees the client addresses
+func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		networkServices: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	rv := &twicenatServer{
+		vppConn:         vppConn,
+		networkServices: o.networkServices,
+	}
+	if o.pool != nil {
+		rv.pool = &addressPool{pool: ippool.NewWithNet(o.pool)}
+	}
+	return rv
+}
+
+func (t *twicenatServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if !t.translates(request.GetConnection().GetNetworkService()) {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	ipContext := request.GetConnection().GetContext().GetIpContext()
+	m, loaded := load(ctx, metadata.IsClient(t))
+	var assigned map[string]struct{}
+	if loaded {
+		assigned = m.assigned
+	}
+	if loaded && !m.matches(ipContext.GetSrcIpAddrs()) {
+		t.release(ctx, m)
+		_, _ = loadAndDelete(ctx, metadata.IsClient(t))
+		loaded = false
+	}
+	if !loaded {
+		var err error
+		if m, err = newMapping(ctx, t.pool, ipContext.GetSrcIpAddrs(), assigned); err != nil {
+			return nil, err
+		}
+	}
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	translate(ipContext, m.original)
+	conn, err := next.Server(ctx).Request(ctx, request)
+	translate(ipContext, m.unique)
+	if err != nil {
+		if !loaded {
+			m.release(t.pool)
+		}
+		return nil, err
+	}
+	translate(conn.GetContext().GetIpContext(), m.unique)
+	// The addresses allocated by the endpoint IPAM are sent back as is on the refreshes, so the endpoint keeps them
+	m.assign(conn.GetContext().GetIpContext().GetSrcIpAddrs())
+
+	if !loaded {
+		store(ctx, metadata.IsClient(t), m)
+	}
+	// The client interface may appear on a later refresh, so the bindings are retried until they are created
+	if swIfIndex, ok := ifindex.Load(ctx, true); ok && len(m.original) > 0 && len(m.bindings) == 0 {
+		if err := bind(ctx, t.vppConn, m, swIfIndex); err != nil {
+			closeCtx, cancelClose := postponeCtxFunc()
+			defer cancelClose()
+
+			if _, closeErr := t.Close(closeCtx, conn); closeErr != nil {
+				err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+			}
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+func (t *twicenatServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if m, ok := loadAndDelete(ctx, metadata.IsClient(t)); ok {
+		t.release(ctx, m)
+		conn = conn.Clone()
+		translate(conn.GetContext().GetIpContext(), m.original)
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (t *twicenatServer) translates(networkService string) bool {
+	if t.pool == nil {
+		return false
+	}
+	if len(t.networkServices) == 0 {
+		return true
+	}
+	_, ok := t.networkServices[networkService]
+	return ok
+}
+
+func (t *twicenatServer) release(ctx context.Context, m *mapping) {
+	if err := unbind(ctx, t.vppConn, m); err != nil {
+		log.FromContext(ctx).WithField("twicenat", "server").Errorf("failed to delete the PNAT bindings: %s", err.Error())
+	}
+	m.release(t.pool)
+}
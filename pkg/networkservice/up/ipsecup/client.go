@@ -15,6 +15,7 @@
 // limitations under the License.
 
 // Package ipsecup provides chain elements that wait the 'up' of the IPSec interface
+// and start healing of the connection when the interface goes down
 package ipsecup
 
 import (
@@ -26,13 +27,20 @@ import (
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/begin"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	ipsecmech "github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 )
 
+type key struct{}
+
 type ipsecUpClient struct {
 	ctx     context.Context
 	vppConn api.Connection
@@ -65,11 +73,47 @@ func (u *ipsecUpClient) Request(ctx context.Context, request *networkservice.Net
 
 			return nil, err
 		}
+		u.monitorLinkDown(ctx)
 	}
 
 	return conn, nil
 }
 
 func (u *ipsecUpClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if v, ok := metadata.Map(ctx, metadata.IsClient(u)).LoadAndDelete(key{}); ok {
+		if cancel, ok := v.(context.CancelFunc); ok {
+			cancel()
+		}
+	}
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
+
+// monitorLinkDown - starts healing of the connection when the link of the IPSec interface goes down
+func (u *ipsecUpClient) monitorLinkDown(ctx context.Context) {
+	swIfIndex, ok := ifindex.Load(ctx, metadata.IsClient(u))
+	if !ok {
+		return
+	}
+	if _, ok := metadata.Map(ctx, metadata.IsClient(u)).Load(key{}); ok {
+		return
+	}
+
+	cancelCtx, cancel := context.WithCancel(u.ctx)
+	factory := begin.FromContext(ctx)
+	state := &renegotiation{
+		inProgress: func() bool { return ipsecmech.IsRenegotiating(ctx, metadata.IsClient(u)) },
+		finish:     func() { ipsecmech.DeleteRenegotiation(ctx, metadata.IsClient(u)) },
+	}
+	err := watchLinkDown(cancelCtx, u.vppConn, swIfIndex, state, func() {
+		if cancelCtx.Err() == nil {
+			metadata.Map(ctx, metadata.IsClient(u)).Delete(key{})
+			factory.Request(begin.WithReselect())
+		}
+	})
+	if err != nil {
+		cancel()
+		log.FromContext(ctx).Errorf("failed to monitor the IPSec interface link: %v", err)
+		return
+	}
+	metadata.Map(ctx, metadata.IsClient(u)).Store(key{}, cancel)
+}
@@ -84,3 +84,51 @@ func waitForUpLinkUp(ctx context.Context, vppConn api.Connection, isClient bool)
 		}
 	}
 }
+
+// watchLinkDown - calls onDown once the link of the interface goes down (e.g. the IKEv2 SA is deleted by the dead peer detection).
+// The link going down is ignored while the IKEv2 SA is being renegotiated, the renegotiation is finished once the link is up
+func watchLinkDown(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, renegotiation *renegotiation, onDown func()) error {
+	watcher, err := vppConn.WatchEvent(ctx, &interfaces.SwInterfaceEvent{})
+	if err != nil {
+		return errors.Wrap(err, "failed to watch interfaces.SwInterfaceEvent")
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case rawMsg := <-watcher.Events():
+				msg, ok := rawMsg.(*interfaces.SwInterfaceEvent)
+				if !ok || msg.SwIfIndex != swIfIndex {
+					continue
+				}
+				if msg.Flags&interface_types.IF_STATUS_API_FLAG_LINK_UP != 0 {
+					renegotiation.finish()
+					continue
+				}
+				if renegotiation.inProgress() {
+					log.FromContext(ctx).
+						WithField("swIfIndex", swIfIndex).
+						WithField("msg.Flags", msg.Flags).
+						WithField("vppapi", "SwInterfaceEvent").Debug("IPSec interface link is down during the IKEv2 SA renegotiation")
+					continue
+				}
+				log.FromContext(ctx).
+					WithField("swIfIndex", swIfIndex).
+					WithField("msg.Flags", msg.Flags).
+					WithField("vppapi", "SwInterfaceEvent").Warn("IPSec interface link is down")
+				onDown()
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// renegotiation - the state of the IKEv2 SA renegotiation kept by the ipsec mechanism in the connection metadata
+type renegotiation struct {
+	inProgress func() bool
+	finish     func()
+}
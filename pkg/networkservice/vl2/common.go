@@ -0,0 +1,224 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vl2
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+
+	interfaces "github.com/networkservicemesh/govpp/binapi/interface"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/govpp/binapi/l2"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/l2bridgedomain"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+const noBVI = interface_types.InterfaceIndex(^uint32(0))
+
+/* Add the connection interface to the bridge domain of the network service, creating it for the first connection */
+func join(ctx context.Context, vppConn api.Connection, networkService string, o *options, isClient bool) error {
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	j, joinedBefore := load(ctx, isClient)
+	if joinedBefore && j.swIfIndex == swIfIndex {
+		return nil
+	}
+
+	o.m.mut.Lock()
+	defer o.m.mut.Unlock()
+
+	info, ok := o.m.entries[networkService]
+	if !ok {
+		var err error
+		if info, err = createBridge(ctx, vppConn, o.cfg, o.gateways); err != nil {
+			return err
+		}
+		o.m.entries[networkService] = info
+	}
+	if joinedBefore {
+		// The interface was recreated on refresh, the old one may be already deleted together with its bridge port
+		if err := addDelVppInterfaceBridgeDomain(ctx, vppConn, j.swIfIndex, info.id, 0, l2.L2_API_PORT_TYPE_NORMAL, false); err != nil {
+			log.FromContext(ctx).WithField("vl2", "join").Debugf("failed to remove the old interface %v: %v", j.swIfIndex, err)
+		}
+	} else {
+		info.count++
+	}
+	store(ctx, isClient, &joined{
+		networkService: networkService,
+		swIfIndex:      swIfIndex,
+	})
+	return addDelVppInterfaceBridgeDomain(ctx, vppConn, swIfIndex, info.id, o.shg, l2.L2_API_PORT_TYPE_NORMAL, true)
+}
+
+/* Remove the connection interface from the bridge domain, deleting it with the last connection */
+func leave(ctx context.Context, vppConn api.Connection, m *Map, isClient bool) error {
+	j, ok := loadAndDelete(ctx, isClient)
+	if !ok {
+		return nil
+	}
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	info, ok := m.entries[j.networkService]
+	if !ok {
+		return nil
+	}
+	err := addDelVppInterfaceBridgeDomain(ctx, vppConn, j.swIfIndex, info.id, 0, l2.L2_API_PORT_TYPE_NORMAL, false)
+
+	info.count--
+	if info.count > 0 {
+		return err
+	}
+	delete(m.entries, j.networkService)
+	if delErr := deleteBridge(ctx, vppConn, info); delErr != nil {
+		return delErr
+	}
+	return err
+}
+
+func createBridge(ctx context.Context, vppConn api.Connection, cfg *l2bridgedomain.Config, gateways []*net.IPNet) (*bridgeInfo, error) {
+	bridgeID, err := l2bridgedomain.CreateBridgeDomain(ctx, vppConn, cfg)
+	if err != nil {
+		return nil, err
+	}
+	info := &bridgeInfo{
+		id:  bridgeID,
+		bvi: noBVI,
+		cfg: cfg,
+	}
+	if len(gateways) == 0 {
+		return info, nil
+	}
+
+	if info.bvi, err = createBVI(ctx, vppConn, bridgeID, gateways); err != nil {
+		info.bvi = noBVI
+		if delErr := deleteBridge(ctx, vppConn, info); delErr != nil {
+			err = errors.Wrapf(err, "failed to delete the bridge domain: %s", delErr.Error())
+		}
+		return nil, err
+	}
+	return info, nil
+}
+
+func deleteBridge(ctx context.Context, vppConn api.Connection, info *bridgeInfo) error {
+	if info.bvi != noBVI {
+		if err := addDelVppInterfaceBridgeDomain(ctx, vppConn, info.bvi, info.id, 0, l2.L2_API_PORT_TYPE_BVI, false); err != nil {
+			return err
+		}
+		now := time.Now()
+		if _, err := interfaces.NewServiceClient(vppConn).DeleteLoopback(ctx, &interfaces.DeleteLoopback{
+			SwIfIndex: info.bvi,
+		}); err != nil {
+			return errors.Wrap(err, "vppapi DeleteLoopback returned error")
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", info.bvi).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "DeleteLoopback").Debug("completed")
+	}
+	return l2bridgedomain.DeleteBridgeDomain(ctx, vppConn, info.id, info.cfg)
+}
+
+/* Create the BVI loopback carrying the gateway IPs of the bridge domain */
+func createBVI(ctx context.Context, vppConn api.Connection, bridgeID uint32, gateways []*net.IPNet) (interface_types.InterfaceIndex, error) {
+	now := time.Now()
+	reply, err := interfaces.NewServiceClient(vppConn).CreateLoopback(ctx, &interfaces.CreateLoopback{})
+	if err != nil {
+		return noBVI, errors.Wrap(err, "vppapi CreateLoopback returned error")
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", reply.SwIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "CreateLoopback").Debug("completed")
+	bvi := reply.SwIfIndex
+
+	if err := configureBVI(ctx, vppConn, bvi, bridgeID, gateways); err != nil {
+		if _, delErr := interfaces.NewServiceClient(vppConn).DeleteLoopback(ctx, &interfaces.DeleteLoopback{
+			SwIfIndex: bvi,
+		}); delErr != nil {
+			err = errors.Wrapf(err, "vppapi DeleteLoopback returned error: %s", delErr.Error())
+		}
+		return noBVI, err
+	}
+	return bvi, nil
+}
+
+func configureBVI(ctx context.Context, vppConn api.Connection, bvi interface_types.InterfaceIndex, bridgeID uint32, gateways []*net.IPNet) error {
+	if err := addDelVppInterfaceBridgeDomain(ctx, vppConn, bvi, bridgeID, 0, l2.L2_API_PORT_TYPE_BVI, true); err != nil {
+		return err
+	}
+	for _, gateway := range gateways {
+		now := time.Now()
+		if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceAddDelAddress(ctx, &interfaces.SwInterfaceAddDelAddress{
+			SwIfIndex: bvi,
+			IsAdd:     true,
+			Prefix:    types.ToVppAddressWithPrefix(gateway),
+		}); err != nil {
+			return errors.Wrap(err, "vppapi SwInterfaceAddDelAddress returned error")
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", bvi).
+			WithField("prefix", gateway).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "SwInterfaceAddDelAddress").Debug("completed")
+	}
+	now := time.Now()
+	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetFlags(ctx, &interfaces.SwInterfaceSetFlags{
+		SwIfIndex: bvi,
+		Flags:     interface_types.IF_STATUS_API_FLAG_ADMIN_UP,
+	}); err != nil {
+		return errors.Wrap(err, "vppapi SwInterfaceSetFlags returned error")
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", bvi).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetFlags").Debug("completed")
+	return nil
+}
+
+func addDelVppInterfaceBridgeDomain(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, bridgeID uint32, shg uint8, portType l2.L2PortType, isAdd bool) error {
+	now := time.Now()
+	if _, err := l2.NewServiceClient(vppConn).SwInterfaceSetL2Bridge(ctx, &l2.SwInterfaceSetL2Bridge{
+		RxSwIfIndex: swIfIndex,
+		Enable:      isAdd,
+		BdID:        bridgeID,
+		Shg:         shg,
+		PortType:    portType,
+	}); err != nil {
+		return errors.Wrap(err, "vppapi SwInterfaceSetL2Bridge returned error")
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("bridgeID", bridgeID).
+		WithField("isAdd", isAdd).
+		WithField("shg", shg).
+		WithField("portType", portType).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetL2Bridge").Debug("completed")
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vl2
+
+import (
+	"net"
+	"sync"
+
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/l2bridgedomain"
+)
+
+type bridgeInfo struct {
+	id uint32
+	// bvi - the BVI loopback of the bridge domain, ^0 if there is none
+	bvi   interface_types.InterfaceIndex
+	count uint32
+	cfg   *l2bridgedomain.Config
+}
+
+// Map stores the bridge domains by NetworkServiceName
+type Map struct {
+	entries map[string]*bridgeInfo
+	mut     sync.Mutex
+}
+
+// NewMap creates bridge domain map
+func NewMap() *Map {
+	return &Map{
+		entries: make(map[string]*bridgeInfo),
+	}
+}
+
+type options struct {
+	m        *Map
+	cfg      *l2bridgedomain.Config
+	shg      uint8
+	gateways []*net.IPNet
+}
+
+// Option is an option pattern for vl2 server
+type Option func(o *options)
+
+// WithSharedMap - sets shared bridge domain map
+func WithSharedMap(m *Map) Option {
+	return func(o *options) {
+		o.m = m
+	}
+}
+
+// WithBridgeDomainConfig - sets the config of the bridge domains created for the network services. The ARP
+// termination entries are not populated by vl2. Default: l2bridgedomain.DefaultConfig()
+func WithBridgeDomainConfig(cfg *l2bridgedomain.Config) Option {
+	return func(o *options) {
+		o.cfg = cfg
+	}
+}
+
+// WithSplitHorizonGroup - puts the connection interfaces to the split horizon group, so that they only exchange the
+// frames with the BVI and with the interfaces of other groups. 0 - no split horizon
+func WithSplitHorizonGroup(shg uint8) Option {
+	return func(o *options) {
+		o.shg = shg
+	}
+}
+
+// WithGateway - adds a BVI loopback carrying the gateway IPs to the bridge domain of each network service
+func WithGateway(gateways ...*net.IPNet) Option {
+	return func(o *options) {
+		o.gateways = gateways
+	}
+}
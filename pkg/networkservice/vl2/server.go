@@ -0,0 +1,83 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vl2
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/l2bridgedomain"
+)
+
+type vl2Server struct {
+	vppConn api.Connection
+	opts    *options
+}
+
+// NewServer creates a NetworkServiceServer chain element adding the vpp interface of every connection to the bridge
+// domain of its network service
+func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		m:   NewMap(),
+		cfg: l2bridgedomain.DefaultConfig(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &vl2Server{
+		vppConn: vppConn,
+		opts:    o,
+	}
+}
+
+func (v *vl2Server) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := join(ctx, v.vppConn, conn.GetNetworkService(), v.opts, metadata.IsClient(v)); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := v.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (v *vl2Server) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if err := leave(ctx, v.vppConn, v.opts.m, metadata.IsClient(v)); err != nil {
+		log.FromContext(ctx).WithField("vl2", "server").Errorf("failed to leave the bridge domain: %s", err.Error())
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -28,18 +28,24 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/govpp/binapi/cnat"
 	"github.com/networkservicemesh/govpp/binapi/ip_types"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	registryclient "github.com/networkservicemesh/sdk/pkg/registry/chains/client"
 	registryrecvfd "github.com/networkservicemesh/sdk/pkg/registry/common/recvfd"
 	registrysendfd "github.com/networkservicemesh/sdk/pkg/registry/common/sendfd"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
 type vl3lbClient struct {
-	port       uint16
-	targetPort uint16
-	protocol   ip_types.IPProto
-	selector   map[string]string
+	mappings     []*Mapping
+	selector     map[string]string
+	lbType       cnat.CnatLbType
+	weightLabel  string
+	drainLabel   string
+	drainTimeout time.Duration
+	healthCheck  *HealthCheck
+	healthDial   DialFunc
 
 	chainCtx          context.Context
 	vppConn           api.Connection
@@ -55,11 +61,25 @@ func NewClient(chainCtx context.Context, vppConn api.Connection, options ...Opti
 		targetPort: 80,
 		protocol:   ip_types.IP_API_PROTO_TCP,
 		selector:   make(map[string]string),
+		lbType:     cnat.CNAT_LB_TYPE_MAGLEV,
 		clientURL:  &url.URL{Scheme: "unix", Host: "connect.to.socket"},
 	}
 	for _, opt := range options {
 		opt(opts)
 	}
+	if opts.lbType != cnat.CNAT_LB_TYPE_DEFAULT && opts.lbType != cnat.CNAT_LB_TYPE_MAGLEV {
+		log.FromContext(chainCtx).Fatalf("vl3lbClient unsupported lb type: %v", opts.lbType)
+	}
+	if opts.healthCheck != nil && opts.healthDial == nil {
+		log.FromContext(chainCtx).Fatal("vl3lbClient health check requires a dial function")
+	}
+	if len(opts.mappings) == 0 {
+		opts.mappings = []*Mapping{{
+			Protocol:   opts.protocol,
+			Port:       opts.port,
+			TargetPort: opts.targetPort,
+		}}
+	}
 
 	nseRegistryClient := registryclient.NewNetworkServiceEndpointRegistryClient(chainCtx,
 		registryclient.WithClientURL(opts.clientURL),
@@ -71,10 +91,14 @@ func NewClient(chainCtx context.Context, vppConn api.Connection, options ...Opti
 	)
 
 	return &vl3lbClient{
-		port:              opts.port,
-		targetPort:        opts.targetPort,
-		protocol:          opts.protocol,
+		mappings:          opts.mappings,
 		selector:          opts.selector,
+		lbType:            opts.lbType,
+		weightLabel:       opts.weightLabel,
+		drainLabel:        opts.drainLabel,
+		drainTimeout:      opts.drainTimeout,
+		healthCheck:       opts.healthCheck,
+		healthDial:        opts.healthDial,
 		chainCtx:          chainCtx,
 		vppConn:           vppConn,
 		nseRegistryClient: nseRegistryClient,
@@ -19,6 +19,8 @@ package vl3lb
 import (
 	"context"
 	"net/url"
+	"strconv"
+	"sync"
 
 	"google.golang.org/grpc"
 
@@ -54,10 +56,24 @@ func (lb *vl3lbClient) balanceService(ctx context.Context, conn *networkservice.
 		return
 	}
 
-	lbVpp := newHandler(lb.vppConn, &endpoint{
-		IP:   conn.GetContext().GetIpContext().GetSrcIPNets()[0].IP,
-		Port: lb.port,
-	}, lb.protocol)
+	lbVpp := newHandler(lb.vppConn, conn.GetContext().GetIpContext().GetSrcIPNets()[0].IP, lb.mappings, lb.lbType, lb.drainTimeout)
+
+	var wg sync.WaitGroup
+	defer func() {
+		// Delete the translations of the load balancer when all the NSEs are not monitored anymore
+		wg.Wait()
+		if err := lbVpp.deleteTranslations(context.Background()); err != nil {
+			loggerLb.Errorf("deleteTranslations error: %v", err.Error())
+		}
+	}()
+
+	if lb.healthCheck != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lbVpp.checkHealth(ctx, lb.healthCheck, lb.healthDial)
+		}()
+	}
 
 	monitoredNSEs := make(map[string]string)
 	for {
@@ -76,7 +92,11 @@ func (lb *vl3lbClient) balanceService(ctx context.Context, conn *networkservice.
 		}
 		monitoredNSEs[msg.GetNetworkServiceEndpoint().GetName()] = ""
 
-		go lb.balanceNSE(ctx, loggerLb, lbVpp, msg.NetworkServiceEndpoint)
+		wg.Add(1)
+		go func(nse *registry.NetworkServiceEndpoint) {
+			defer wg.Done()
+			lb.balanceNSE(ctx, loggerLb, lbVpp, nse)
+		}(msg.NetworkServiceEndpoint)
 	}
 }
 
@@ -122,7 +142,7 @@ func (lb *vl3lbClient) balanceNSE(ctx context.Context, loggerLb log.Logger, lbVp
 		}
 
 		// 4. Filter out those connections that contain 'Selector' labels
-		add, del := filterConnections(event, lb.selector, lb.targetPort)
+		add, del := filterConnections(event, lb.selector, lb.weightLabel, lb.drainLabel)
 		// 6. Configure VPP load balancing
 		if err = lbVpp.addServers(ctx, nse.Name, add); err != nil {
 			logger.Errorf("addServers error: %v", err.Error())
@@ -133,7 +153,7 @@ func (lb *vl3lbClient) balanceNSE(ctx context.Context, loggerLb log.Logger, lbVp
 	}
 }
 
-func filterConnections(event *networkservice.ConnectionEvent, selector map[string]string, targetPort uint16) (add map[string]*endpoint, del []string) {
+func filterConnections(event *networkservice.ConnectionEvent, selector map[string]string, weightLabel, drainLabel string) (add map[string]*endpoint, del []string) {
 	add = make(map[string]*endpoint)
 	for _, eventConnection := range event.Connections {
 		for k, v := range selector {
@@ -147,8 +167,9 @@ func filterConnections(event *networkservice.ConnectionEvent, selector map[strin
 						del = append(del, eventConnection.Id)
 					default:
 						add[eventConnection.Id] = &endpoint{
-							IP:   eventConnection.GetContext().GetIpContext().GetSrcIPNets()[0].IP,
-							Port: targetPort,
+							IP:       eventConnection.GetContext().GetIpContext().GetSrcIPNets()[0].IP,
+							Weight:   weight(eventConnection.Labels, weightLabel),
+							Draining: drainLabel != "" && eventConnection.Labels[drainLabel] == "true",
 						}
 					}
 					break
@@ -158,3 +179,15 @@ func filterConnections(event *networkservice.ConnectionEvent, selector map[strin
 	}
 	return
 }
+
+// weight returns the weight of the real server from the connection labels, 1 if there is no valid weight label
+func weight(labels map[string]string, weightLabel string) uint32 {
+	if weightLabel == "" {
+		return 1
+	}
+	w, err := strconv.ParseUint(labels[weightLabel], 10, 32)
+	if err != nil {
+		return 1
+	}
+	return uint32(w)
+}
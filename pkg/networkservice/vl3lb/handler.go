@@ -20,9 +20,12 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/edwarnicke/genericsync"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
 	"go.fd.io/govpp/api"
 
 	"github.com/networkservicemesh/govpp/binapi/cnat"
@@ -34,53 +37,68 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
+// maxWeight - cnat paths have no weight, so the path of the real server is repeated weight times
+const maxWeight = 32
+
 // endpoint contains the main fields for the VPP plugin
 type endpoint struct {
-	IP   net.IP
-	Port uint16
+	IP     net.IP
+	Weight uint32
+	// Draining - the real server gets no new sessions, but the existing sessions keep flowing
+	Draining bool
 }
 
 // equals returns true if Endpoints are equal
 func (e *endpoint) equals(endpoint *endpoint) bool {
-	return e.IP.Equal(endpoint.IP) && e.Port == endpoint.Port
+	return e.IP.Equal(endpoint.IP) && e.Weight == endpoint.Weight && e.Draining == endpoint.Draining
 }
 
 // equals returns true if Endpoints are equal
 func (e *endpoint) string() string {
-	return fmt.Sprintf("%s:%d", e.IP.String(), e.Port)
+	return fmt.Sprintf("%s weight %d draining %t", e.IP.String(), e.Weight, e.Draining)
 }
 
 // handler works with load balancer servers. It is based on CNAT VPP-plugin
 type handler struct {
-	vppConn    api.Connection
-	lbEndpoint cnat.CnatEndpoint
-	proto      ip_types.IPProto
-	isRealIP   uint8
-	lbType     cnat.CnatLbType
+	vppConn  api.Connection
+	vip      ip_types.Address
+	mappings []*Mapping
+	isRealIP uint8
+	lbType   cnat.CnatLbType
 
 	// [vl3-NSE] --> [connID]*Endpoint
 	// We store it this way because the plugin does not add, but only updates existing entries. Therefore, to add/delete one entry, we must also pass the old ones.
 	servers genericsync.Map[string, *genericsync.Map[string, *endpoint]]
+
+	drainTimeout time.Duration
+
+	// translations - the IDs VPP returned for the translations of the mappings
+	translations map[Mapping]uint32
+	// failures - the number of the consecutive failed health checks per real server IP
+	failures  map[string]uint32
+	threshold uint32
+	// closed - the translations are deleted and must not be updated anymore
+	closed bool
+	mu     sync.Mutex
 }
 
 // newHandler creates a Handler.
-// endpoint contains Load Balancer parameters. The clients can reach the LB with endpoint.Addr:endpoint:Port
-func newHandler(vppConn api.Connection, endpoint *endpoint, proto ip_types.IPProto) *handler {
+// The clients can reach the LB with vip:mapping.Port for each of the mappings
+func newHandler(vppConn api.Connection, vip net.IP, mappings []*Mapping, lbType cnat.CnatLbType, drainTimeout time.Duration) *handler {
 	return &handler{
-		vppConn: vppConn,
-		lbEndpoint: cnat.CnatEndpoint{
-			Addr:      types.ToVppAddress(endpoint.IP),
-			SwIfIndex: interface_types.InterfaceIndex(^uint32(0)),
-			Port:      endpoint.Port,
-		},
-		proto:    proto,
-		isRealIP: 1,
-		lbType:   cnat.CNAT_LB_TYPE_MAGLEV,
+		vppConn:      vppConn,
+		vip:          types.ToVppAddress(vip),
+		mappings:     mappings,
+		isRealIP:     1,
+		lbType:       lbType,
+		drainTimeout: drainTimeout,
+		translations: make(map[Mapping]uint32),
+		failures:     make(map[string]uint32),
 	}
 }
 
 func cnatTranslationString(c *cnat.CnatTranslation) string {
-	str := fmt.Sprintf("%s:%d", c.Vip.Addr.String(), c.Vip.Port)
+	str := fmt.Sprintf("%s:%d/%s", c.Vip.Addr.String(), c.Vip.Port, c.IPProto)
 	for _, p := range c.Paths {
 		str = fmt.Sprintf("%s to %s -> %s:%d, ", str, p.SrcEp.Addr, p.DstEp.Addr, p.DstEp.Port)
 	}
@@ -109,8 +127,44 @@ func (c *handler) addServers(ctx context.Context, vl3NSEName string, add map[str
 	return err
 }
 
-// deleteServers deletes the real servers from the VPP plugin
+// deleteServers deletes the real servers from the VPP plugin. The real servers are draining for the drain timeout
+// before the deletion
 func (c *handler) deleteServers(ctx context.Context, vl3NSEName string, del []string) (err error) {
+	if c.drainTimeout == 0 {
+		return c.removeServers(ctx, vl3NSEName, del, nil)
+	}
+	realServers, ok := c.servers.Load(vl3NSEName)
+	if !ok {
+		return nil
+	}
+
+	draining := make(map[string]*endpoint)
+	for _, id := range del {
+		if e, ok := realServers.Load(id); ok {
+			drainingEndpoint := &endpoint{IP: e.IP, Weight: e.Weight, Draining: true}
+			realServers.Store(id, drainingEndpoint)
+			draining[id] = drainingEndpoint
+			log.FromContext(ctx).WithField("vl3lb", "DeleteServers").
+				WithField("vL3NSE", vl3NSEName).
+				WithField("serverID", id).Debugf("draining")
+		}
+	}
+	if len(draining) == 0 {
+		return nil
+	}
+
+	time.AfterFunc(c.drainTimeout, func() {
+		removeCtx := log.WithLog(context.Background(), log.FromContext(ctx))
+		if err := c.removeServers(removeCtx, vl3NSEName, del, draining); err != nil {
+			log.FromContext(ctx).WithField("vl3lb", "DeleteServers").Errorf("removeServers error: %v", err.Error())
+		}
+	})
+	return c.updateVPPCnat(ctx)
+}
+
+// removeServers removes the real servers from the VPP plugin. If the draining servers are set, only the real servers
+// still draining since then are removed
+func (c *handler) removeServers(ctx context.Context, vl3NSEName string, del []string, draining map[string]*endpoint) (err error) {
 	realServers, ok := c.servers.Load(vl3NSEName)
 	if !ok {
 		return nil
@@ -118,6 +172,11 @@ func (c *handler) deleteServers(ctx context.Context, vl3NSEName string, del []st
 
 	updateRequired := false
 	for _, id := range del {
+		if draining != nil {
+			if e, ok := realServers.Load(id); !ok || e != draining[id] {
+				continue
+			}
+		}
 		realServers.Delete(id)
 		updateRequired = true
 		log.FromContext(ctx).WithField("vl3lb", "DeleteServers").
@@ -158,59 +217,141 @@ func (c *handler) getServerIDsByVL3Name(vl3NSEName string) []string {
 }
 
 func (c *handler) updateVPPCnat(ctx context.Context) error {
-	var paths []cnat.CnatEndpointTuple
+	var servers []*endpoint
 	c.servers.Range(func(key string, realServers *genericsync.Map[string, *endpoint]) bool {
 		realServers.Range(func(key string, s *endpoint) bool {
+			servers = append(servers, s)
+			return true
+		})
+		return true
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+	var err error
+	for _, m := range c.mappings {
+		if updateErr := c.updateTranslation(ctx, m, servers); updateErr != nil {
+			err = multierror.Append(err, updateErr)
+		}
+	}
+	return err
+}
+
+// deleteTranslations deletes all the translations of the load balancer
+func (c *handler) deleteTranslations(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	var err error
+	for _, m := range c.mappings {
+		if delErr := c.updateTranslation(ctx, m, nil); delErr != nil {
+			err = multierror.Append(err, delErr)
+		}
+	}
+	return err
+}
+
+// updateTranslation updates the translation of the mapping. The translation is deleted if there are no servers getting
+// new sessions and no draining servers, e.g. all the servers have the weight 0.
+// The draining and unhealthy real servers get no new sessions unless all the non-draining real servers are unhealthy
+func (c *handler) updateTranslation(ctx context.Context, m *Mapping, servers []*endpoint) error {
+	var active, healthy []*endpoint
+	draining := false
+	for _, s := range servers {
+		if s.Draining {
+			draining = true
+			continue
+		}
+		if s.Weight == 0 {
+			continue
+		}
+		active = append(active, s)
+		if c.healthy(s.IP) {
+			healthy = append(healthy, s)
+		}
+	}
+	// The failed health checks alone never leave the mapping without paths
+	if len(healthy) > 0 {
+		active = healthy
+	} else if len(active) > 0 {
+		log.FromContext(ctx).WithField("mapping", *m).Warn("all the real servers failed the health checks, keeping them")
+	}
+	// The translation is kept for the draining servers, so that their existing sessions keep flowing
+	if len(active) == 0 && !draining {
+		return c.deleteTranslation(ctx, m)
+	}
+
+	var paths []cnat.CnatEndpointTuple
+	for _, s := range active {
+		weight := s.Weight
+		if weight > maxWeight {
+			weight = maxWeight
+		}
+		for i := uint32(0); i < weight; i++ {
 			paths = append(paths, cnat.CnatEndpointTuple{
 				DstEp: cnat.CnatEndpoint{
 					Addr:      types.ToVppAddress(s.IP),
 					SwIfIndex: interface_types.InterfaceIndex(^uint32(0)),
-					Port:      s.Port,
+					Port:      m.TargetPort,
 				},
 				SrcEp: cnat.CnatEndpoint{
-					Addr:      c.lbEndpoint.Addr,
+					Addr:      c.vip,
 					SwIfIndex: interface_types.InterfaceIndex(^uint32(0)),
 				},
 			})
-			return true
-		})
-		return true
-	})
-
-	if len(paths) == 0 {
-		now := time.Now()
-		cnatTranslationDel := cnat.CnatTranslationDel{ID: 0}
-		_, err := cnat.NewServiceClient(c.vppConn).CnatTranslationDel(ctx, &cnatTranslationDel)
-		if err != nil {
-			return err
 		}
-
-		log.FromContext(ctx).
-			WithField("translationID", cnatTranslationDel.ID).
-			WithField("duration", time.Since(now)).
-			WithField("vppapi", "CnatTranslationDel").Debug("completed")
-		return nil
 	}
 
 	now := time.Now()
 	cnatTranslation := cnat.CnatTranslation{
-		Vip:      c.lbEndpoint,
-		IPProto:  c.proto,
+		Vip: cnat.CnatEndpoint{
+			Addr:      c.vip,
+			SwIfIndex: interface_types.InterfaceIndex(^uint32(0)),
+			Port:      m.Port,
+		},
+		IPProto:  m.Protocol,
 		IsRealIP: c.isRealIP,
-		ID:       0,
+		ID:       c.translations[*m],
 		LbType:   c.lbType,
 		NPaths:   uint32(len(paths)),
 		Paths:    paths,
 	}
 	reply, err := cnat.NewServiceClient(c.vppConn).CnatTranslationUpdate(ctx, &cnat.CnatTranslationUpdate{Translation: cnatTranslation})
 	if err != nil {
-		return err
+		return errors.Wrap(err, "vppapi CnatTranslationUpdate returned error")
 	}
 	cnatTranslation.ID = reply.ID
+	c.translations[*m] = reply.ID
 
 	log.FromContext(ctx).
+		WithField("translationID", cnatTranslation.ID).
 		WithField("translation", cnatTranslationString(&cnatTranslation)).
 		WithField("duration", time.Since(now)).
 		WithField("vppapi", "CnatTranslationUpdate").Debug("completed")
 	return nil
 }
+
+// deleteTranslation deletes the translation of the mapping if it exists
+func (c *handler) deleteTranslation(ctx context.Context, m *Mapping) error {
+	id, ok := c.translations[*m]
+	if !ok {
+		return nil
+	}
+	now := time.Now()
+	cnatTranslationDel := cnat.CnatTranslationDel{ID: id}
+	if _, err := cnat.NewServiceClient(c.vppConn).CnatTranslationDel(ctx, &cnatTranslationDel); err != nil {
+		return errors.Wrap(err, "vppapi CnatTranslationDel returned error")
+	}
+	delete(c.translations, *m)
+
+	log.FromContext(ctx).
+		WithField("translationID", cnatTranslationDel.ID).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "CnatTranslationDel").Debug("completed")
+	return nil
+}
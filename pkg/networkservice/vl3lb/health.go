@@ -0,0 +1,137 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vl3lb
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/networkservicemesh/govpp/binapi/ip_types"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// checkHealth checks the real servers every interval until the context is done. The real servers failing the checks
+// threshold times in a row get no new sessions until the next successful check
+func (c *handler) checkHealth(ctx context.Context, hc *HealthCheck, dial DialFunc) {
+	logger := log.FromContext(ctx).WithField("vl3lb", "HealthCheck")
+	var port uint16
+	for _, m := range c.mappings {
+		if m.Protocol == ip_types.IP_API_PROTO_TCP {
+			port = m.TargetPort
+			break
+		}
+	}
+	if port == 0 {
+		logger.Warn("no TCP mapping to check the health of the real servers")
+		return
+	}
+
+	c.mu.Lock()
+	c.threshold = hc.Threshold
+	if c.threshold == 0 {
+		c.threshold = 1
+	}
+	c.mu.Unlock()
+
+	ticker := time.NewTicker(hc.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		results := make(map[string]bool)
+		for _, ip := range c.serverIPs() {
+			results[ip.String()] = probe(ctx, hc, dial, net.JoinHostPort(ip.String(), strconv.Itoa(int(port))))
+		}
+
+		if c.report(results) {
+			logger.Debug("health of the real servers changed")
+			if err := c.updateVPPCnat(ctx); err != nil {
+				logger.Errorf("updateVPPCnat error: %v", err.Error())
+			}
+		}
+	}
+}
+
+// probe returns true if the TCP connection to the address is established within the timeout
+func probe(ctx context.Context, hc *HealthCheck, dial DialFunc, address string) bool {
+	if hc.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, hc.Timeout)
+		defer cancel()
+	}
+	conn, err := dial(ctx, "tcp", address)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
+// report saves the results of the health checks, returns true if the health of any real server has changed
+func (c *handler) report(results map[string]bool) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	changed := false
+	for ip := range c.failures {
+		if _, ok := results[ip]; !ok {
+			delete(c.failures, ip)
+		}
+	}
+	for ip, ok := range results {
+		wasHealthy := c.failures[ip] < c.threshold
+		if ok {
+			delete(c.failures, ip)
+		} else {
+			c.failures[ip]++
+		}
+		if wasHealthy != (c.failures[ip] < c.threshold) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// healthy returns true if the real server gets new sessions. Must be called under the lock
+func (c *handler) healthy(ip net.IP) bool {
+	return c.threshold == 0 || c.failures[ip.String()] < c.threshold
+}
+
+// serverIPs returns the IPs of all the real servers
+func (c *handler) serverIPs() []net.IP {
+	var ips []net.IP
+	known := make(map[string]struct{})
+	c.servers.Range(func(key string, realServers *genericsync.Map[string, *endpoint]) bool {
+		realServers.Range(func(key string, s *endpoint) bool {
+			if _, ok := known[s.IP.String()]; !ok {
+				known[s.IP.String()] = struct{}{}
+				ips = append(ips, s.IP)
+			}
+			return true
+		})
+		return true
+	})
+	return ips
+}
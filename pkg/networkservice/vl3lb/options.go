@@ -17,19 +17,48 @@
 package vl3lb
 
 import (
+	"context"
+	"net"
 	"net/url"
 	"time"
 
 	"google.golang.org/grpc"
 
+	"github.com/networkservicemesh/govpp/binapi/cnat"
 	"github.com/networkservicemesh/govpp/binapi/ip_types"
 )
 
+// Mapping - exposes the target port of the real servers on the port of the load balancer
+type Mapping struct {
+	Protocol   ip_types.IPProto
+	Port       uint16
+	TargetPort uint16
+}
+
+// HealthCheck - parameters of the L4 health checks of the real servers
+type HealthCheck struct {
+	Interval time.Duration
+	Timeout  time.Duration
+	// Threshold - the number of the consecutive failed checks after which the real server gets no new sessions
+	Threshold uint32
+}
+
+// DialFunc - connects to the address of the real server, e.g. net.Dialer.DialContext running in the network namespace
+// of the kernel interface of the vl3 network
+type DialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
 type vl3LBOptions struct {
-	port       uint16
-	targetPort uint16
-	protocol   ip_types.IPProto
-	selector   map[string]string
+	port         uint16
+	targetPort   uint16
+	protocol     ip_types.IPProto
+	mappings     []*Mapping
+	selector     map[string]string
+	lbType       cnat.CnatLbType
+	weightLabel  string
+	drainLabel   string
+	drainTimeout time.Duration
+	healthCheck  *HealthCheck
+	healthDial   DialFunc
 
 	clientURL   *url.URL
 	dialTimeout time.Duration
@@ -63,6 +92,18 @@ func WithProtocol(protocol ip_types.IPProto) Option {
 	}
 }
 
+// WithMapping - adds a protocol and port mapping of the load balancer. WithPort, WithTargetPort and WithProtocol set
+// the only mapping if there are none
+func WithMapping(protocol ip_types.IPProto, port, targetPort uint16) Option {
+	return func(o *vl3LBOptions) {
+		o.mappings = append(o.mappings, &Mapping{
+			Protocol:   protocol,
+			Port:       port,
+			TargetPort: targetPort,
+		})
+	}
+}
+
 // WithSelector - set a load balancer selector
 func WithSelector(selector map[string]string) Option {
 	return func(o *vl3LBOptions) {
@@ -70,6 +111,48 @@ func WithSelector(selector map[string]string) Option {
 	}
 }
 
+// WithLbType - set the cnat load balancing type: CNAT_LB_TYPE_DEFAULT (flow hash) or CNAT_LB_TYPE_MAGLEV.
+// VPP supports no other types. Default: CNAT_LB_TYPE_MAGLEV
+func WithLbType(lbType cnat.CnatLbType) Option {
+	return func(o *vl3LBOptions) {
+		o.lbType = lbType
+	}
+}
+
+// WithWeightLabel - set the label of the real server connection carrying its weight. The weight is 1 if the connection
+// has no label, the real server with the weight 0 gets no new sessions
+func WithWeightLabel(label string) Option {
+	return func(o *vl3LBOptions) {
+		o.weightLabel = label
+	}
+}
+
+// WithDrainLabel - set the label of the real server connection marking it as draining with the "true" value
+func WithDrainLabel(label string) Option {
+	return func(o *vl3LBOptions) {
+		o.drainLabel = label
+	}
+}
+
+// WithDrainTimeout - set the time the deleted real servers are draining: they get no new sessions, but the existing
+// sessions keep flowing. The real servers are deleted immediately by default
+func WithDrainTimeout(drainTimeout time.Duration) Option {
+	return func(o *vl3LBOptions) {
+		o.drainTimeout = drainTimeout
+	}
+}
+
+// WithHealthCheck - enables the L4 health checks of the real servers. The checks connect to the target port of the
+// first TCP mapping with dial, it is required and must reach the real servers over the vl3 network. The load
+// balancers with UDP mappings only are not checked, their real servers are never filtered out. The failed checks
+// never leave a mapping without real servers: if all the real servers fail, all of them keep getting new sessions
+func WithHealthCheck(dial DialFunc, healthCheck *HealthCheck) Option {
+	return func(o *vl3LBOptions) {
+		o.healthDial = dial
+		o.healthCheck = healthCheck
+	}
+}
+
 // WithClientURL sets clientURL.
 func WithClientURL(clientURL *url.URL) Option {
 	return func(c *vl3LBOptions) {
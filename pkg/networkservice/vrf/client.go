@@ -38,6 +38,7 @@ type vrfClient struct {
 	vppConn api.Connection
 	loadFn  ifindex.LoadInterfaceFn
 	m       *Map
+	vrfs    map[string]*VRF
 }
 
 // NewClient creates a NetworkServiceClient chain element to create the ip table in vpp
@@ -45,6 +46,7 @@ func NewClient(vppConn api.Connection, opts ...Option) networkservice.NetworkSer
 	o := &options{
 		m:      NewMap(),
 		loadFn: ifindex.Load,
+		vrfs:   make(map[string]*VRF),
 	}
 
 	for _, opt := range opts {
@@ -55,6 +57,7 @@ func NewClient(vppConn api.Connection, opts ...Option) networkservice.NetworkSer
 		vppConn: vppConn,
 		m:       o.m,
 		loadFn:  o.loadFn,
+		vrfs:    o.vrfs,
 	}
 }
 
@@ -68,7 +71,7 @@ func (v *vrfClient) Request(ctx context.Context, request *networkservice.Network
 			t = v.m.ipv6
 		}
 		if _, ok := Load(ctx, metadata.IsClient(v), isIPv6); !ok {
-			vrfID, err := create(ctx, v.vppConn, networkService, t, isIPv6)
+			vrfID, err := create(ctx, v.vppConn, networkService, t, v.vrfs, isIPv6)
 			if err != nil {
 				return nil, err
 			}
@@ -82,7 +85,7 @@ func (v *vrfClient) Request(ctx context.Context, request *networkservice.Network
 
 	conn, err := next.Client(ctx).Request(ctx, request, opts...)
 	if err != nil {
-		delV46(ctx, v.vppConn, v.m, conn.GetNetworkService(), metadata.IsClient(v))
+		delV46(ctx, v.vppConn, v.m, v.vrfs, conn.GetNetworkService(), metadata.IsClient(v))
 		delTableFromMetadataV46(ctx, metadata.IsClient(v))
 
 		return conn, err
@@ -106,7 +109,7 @@ func (v *vrfClient) Request(ctx context.Context, request *networkservice.Network
 }
 
 func (v *vrfClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
-	delV46(ctx, v.vppConn, v.m, conn.GetNetworkService(), metadata.IsClient(v))
+	delV46(ctx, v.vppConn, v.m, v.vrfs, conn.GetNetworkService(), metadata.IsClient(v))
 	_, err := next.Client(ctx).Close(ctx, conn, opts...)
 	delTableFromMetadataV46(ctx, metadata.IsClient(v))
 
@@ -20,8 +20,10 @@ import (
 	"context"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 
+	"github.com/networkservicemesh/govpp/binapi/fib_types"
 	interfaces "github.com/networkservicemesh/govpp/binapi/interface"
 	"github.com/networkservicemesh/govpp/binapi/interface_types"
 	"github.com/networkservicemesh/govpp/binapi/ip"
@@ -30,15 +32,27 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
 )
 
-func create(ctx context.Context, vppConn api.Connection, networkService string, t *vrfMap, isIPv6 bool) (vtfID uint32, err error) {
+func create(ctx context.Context, vppConn api.Connection, networkService string, t *vrfMap, vrfs map[string]*VRF, isIPv6 bool) (vtfID uint32, err error) {
 	t.mut.Lock()
 	defer t.mut.Unlock()
 
 	info, contains := t.entries[networkService]
 	if !contains {
-		vrfID, err := createVPP(ctx, vppConn, isIPv6)
+		var vrfID uint32
+		if vrf, ok := vrfs[networkService]; ok && vrf.TableID != 0 {
+			// The table is deleted with the last connection of the network service, so it can't be shared
+			for name, used := range t.entries {
+				if used.id == vrf.TableID {
+					return ^uint32(0), errors.Errorf("table %d of the network service %s is already used by the network service %s", vrf.TableID, networkService, name)
+				}
+			}
+			vrfID, err = addVPP(ctx, vppConn, networkService, vrf.TableID, isIPv6)
+		} else {
+			vrfID, err = createVPP(ctx, vppConn, networkService, isIPv6)
+		}
 		if err != nil {
 			return vrfID, err
 		}
@@ -47,17 +61,47 @@ func create(ctx context.Context, vppConn api.Connection, networkService string,
 			attached: make(map[interface_types.InterfaceIndex]struct{}),
 		}
 		t.entries[networkService] = info
+
+		if err := leakAddDel(ctx, vppConn, networkService, t, vrfs, isIPv6, true); err != nil {
+			_ = leakAddDel(ctx, vppConn, networkService, t, vrfs, isIPv6, false)
+			delete(t.entries, networkService)
+			_ = delVPP(ctx, vppConn, vrfID, isIPv6)
+			return vrfID, err
+		}
 	}
 
 	return info.id, nil
 }
 
-func createVPP(ctx context.Context, vppConn api.Connection, isIPv6 bool) (uint32, error) {
+func addVPP(ctx context.Context, vppConn api.Connection, networkService string, vrfID uint32, isIPv6 bool) (uint32, error) {
+	now := time.Now()
+	if _, err := ip.NewServiceClient(vppConn).IPTableAddDel(ctx, &ip.IPTableAddDel{
+		IsAdd: true,
+		Table: ip.IPTable{
+			TableID: vrfID,
+			IsIP6:   isIPv6,
+			Name:    networkService,
+		},
+	}); err != nil {
+		return ^uint32(0), errors.Wrap(err, "vppapi IPTableAddDel returned error")
+	}
+	log.FromContext(ctx).
+		WithField("isAdd", true).
+		WithField("vrfID", vrfID).
+		WithField("name", networkService).
+		WithField("isIP6", isIPv6).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IPTableAddDel").Debug("completed")
+	return vrfID, nil
+}
+
+func createVPP(ctx context.Context, vppConn api.Connection, networkService string, isIPv6 bool) (uint32, error) {
 	now := time.Now()
 	reply, err := ip.NewServiceClient(vppConn).IPTableAllocate(ctx, &ip.IPTableAllocate{
 		Table: ip.IPTable{
 			TableID: ^uint32(0),
 			IsIP6:   isIPv6,
+			Name:    networkService,
 		},
 	})
 	if err != nil {
@@ -110,7 +154,7 @@ func attach(ctx context.Context, vppConn api.Connection, networkService string,
 	return nil
 }
 
-func del(ctx context.Context, vppConn api.Connection, networkService string, t *vrfMap, isIPv6, isClient bool) {
+func del(ctx context.Context, vppConn api.Connection, networkService string, t *vrfMap, vrfs map[string]*VRF, isIPv6, isClient bool) {
 	if vrfID, ok := Load(ctx, isClient, isIPv6); ok {
 		t.mut.Lock()
 		if vrfInfo, ok := t.entries[networkService]; ok {
@@ -123,6 +167,9 @@ func del(ctx context.Context, vppConn api.Connection, networkService string, t *
 
 			/* If there are no more clients using the vrf - delete it */
 			if len(vrfInfo.attached) == 1 {
+				if err := leakAddDel(ctx, vppConn, networkService, t, vrfs, isIPv6, false); err != nil {
+					log.FromContext(ctx).WithField("networkService", networkService).Errorf("failed to delete the leaked routes: %s", err.Error())
+				}
 				delete(t.entries, networkService)
 				_ = delVPP(ctx, vppConn, vrfID, isIPv6)
 			}
@@ -151,9 +198,59 @@ func delVPP(ctx context.Context, vppConn api.Connection, vrfID uint32, isIPv6 bo
 		WithField("vppapi", "IPTableAddDel").Debug("completed")
 	return nil
 }
-func delV46(ctx context.Context, vppConn api.Connection, m *Map, networkService string, isClient bool) {
-	del(ctx, vppConn, networkService, m.ipv6, true, isClient)
-	del(ctx, vppConn, networkService, m.ipv4, false, isClient)
+
+// leakAddDel - adds/deletes the routes leaking the prefixes between the VRF of the network service and the existing
+// VRFs it imports from or exports to
+func leakAddDel(ctx context.Context, vppConn api.Connection, networkService string, t *vrfMap, vrfs map[string]*VRF, isIPv6, isAdd bool) error {
+	var err error
+	for _, route := range leakRoutes(vrfs, networkService, isIPv6) {
+		dst, ok := t.entries[route.dst]
+		if !ok {
+			continue
+		}
+		src, ok := t.entries[route.src]
+		if !ok {
+			continue
+		}
+		now := time.Now()
+		if _, routeErr := ip.NewServiceClient(vppConn).IPRouteAddDel(ctx, &ip.IPRouteAddDel{
+			IsAdd: isAdd,
+			Route: ip.IPRoute{
+				TableID: dst.id,
+				Prefix:  types.ToVppPrefix(route.prefix),
+				NPaths:  1,
+				Paths: []fib_types.FibPath{
+					{
+						SwIfIndex: ^uint32(0),
+						TableID:   src.id,
+						Weight:    1,
+						Type:      fib_types.FIB_API_PATH_TYPE_NORMAL,
+						Flags:     fib_types.FIB_API_PATH_FLAG_NONE,
+						Proto:     types.IsV6toFibProto(isIPv6),
+					},
+				},
+			},
+		}); routeErr != nil {
+			err = multierror.Append(err, errors.Wrap(routeErr, "vppapi IPRouteAddDel returned error"))
+			if isAdd {
+				return err
+			}
+			continue
+		}
+		log.FromContext(ctx).
+			WithField("isAdd", isAdd).
+			WithField("prefix", route.prefix).
+			WithField("vrfID", dst.id).
+			WithField("lookupVrfID", src.id).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "IPRouteAddDel").Debug("completed")
+	}
+	return err
+}
+
+func delV46(ctx context.Context, vppConn api.Connection, m *Map, vrfs map[string]*VRF, networkService string, isClient bool) {
+	del(ctx, vppConn, networkService, m.ipv6, vrfs, true, isClient)
+	del(ctx, vppConn, networkService, m.ipv4, vrfs, false, isClient)
 }
 
 func delTableFromMetadataV46(ctx context.Context, isClient bool) {
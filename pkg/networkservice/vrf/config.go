@@ -0,0 +1,75 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vrf
+
+import (
+	"net"
+)
+
+// VRF - configuration of the VRF of a network service
+type VRF struct {
+	// TableID - static ID of the table, 0 - the ID is allocated by VPP
+	TableID uint32
+	// Import - the prefixes of the other VRFs reachable from this VRF
+	Import []*Leak
+	// Export - the prefixes of this VRF reachable from the other VRFs
+	Export []*Leak
+}
+
+// Leak - the prefixes leaked between the VRF and the VRF of the network service
+type Leak struct {
+	NetworkService string
+	Prefixes       []*net.IPNet
+}
+
+// leakRoute - the route in the dst VRF looking the prefix up in the src VRF
+type leakRoute struct {
+	dst    string
+	src    string
+	prefix *net.IPNet
+}
+
+// leakRoutes - returns the leak routes of the network service of the IP family
+func leakRoutes(vrfs map[string]*VRF, networkService string, isIPv6 bool) []*leakRoute {
+	var rv []*leakRoute
+	known := make(map[string]struct{})
+	add := func(dst, src string, prefixes []*net.IPNet) {
+		if dst == src || (dst != networkService && src != networkService) {
+			return
+		}
+		for _, prefix := range prefixes {
+			if prefix == nil || (prefix.IP.To4() == nil) != isIPv6 {
+				continue
+			}
+			key := dst + "/" + src + "/" + prefix.String()
+			if _, ok := known[key]; ok {
+				continue
+			}
+			known[key] = struct{}{}
+			rv = append(rv, &leakRoute{dst: dst, src: src, prefix: prefix})
+		}
+	}
+	for name, vrf := range vrfs {
+		for _, leak := range vrf.Import {
+			add(name, leak.NetworkService, leak.Prefixes)
+		}
+		for _, leak := range vrf.Export {
+			add(leak.NetworkService, name, leak.Prefixes)
+		}
+	}
+	return rv
+}
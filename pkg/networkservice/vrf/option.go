@@ -58,6 +58,7 @@ func NewMap() *Map {
 type options struct {
 	m      *Map
 	loadFn ifindex.LoadInterfaceFn
+	vrfs   map[string]*VRF
 }
 
 // Option is an option pattern for upClient/Server
@@ -76,3 +77,11 @@ func WithLoadInterface(loadFn ifindex.LoadInterfaceFn) Option {
 		o.loadFn = loadFn
 	}
 }
+
+// WithVRF - sets the static table ID and the route leaking of the VRF of the network service. The VPP table is named
+// after the network service. Client and server sharing the map should have the same VRFs
+func WithVRF(networkService string, vrf *VRF) Option {
+	return func(o *options) {
+		o.vrfs[networkService] = vrf
+	}
+}
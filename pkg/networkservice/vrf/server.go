@@ -35,6 +35,7 @@ type vrfServer struct {
 	vppConn api.Connection
 	loadFn  ifindex.LoadInterfaceFn
 	m       *Map
+	vrfs    map[string]*VRF
 }
 
 // NewServer creates a NetworkServiceServer chain element to create the ip table in vpp
@@ -42,6 +43,7 @@ func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkSer
 	o := &options{
 		m:      NewMap(),
 		loadFn: ifindex.Load,
+		vrfs:   make(map[string]*VRF),
 	}
 	for _, opt := range opts {
 		opt(o)
@@ -51,6 +53,7 @@ func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkSer
 		vppConn: vppConn,
 		loadFn:  o.loadFn,
 		m:       o.m,
+		vrfs:    o.vrfs,
 	}
 }
 
@@ -64,7 +67,7 @@ func (v *vrfServer) Request(ctx context.Context, request *networkservice.Network
 			t = v.m.ipv6
 		}
 		if _, ok := Load(ctx, metadata.IsClient(v), isIPv6); !ok {
-			vrfID, err := create(ctx, v.vppConn, networkService, t, isIPv6)
+			vrfID, err := create(ctx, v.vppConn, networkService, t, v.vrfs, isIPv6)
 			if err != nil {
 				return nil, err
 			}
@@ -76,7 +79,7 @@ func (v *vrfServer) Request(ctx context.Context, request *networkservice.Network
 	postponeCtxFunc := postpone.ContextWithValues(ctx)
 	conn, err := next.Server(ctx).Request(ctx, request)
 	if err != nil {
-		delV46(ctx, v.vppConn, v.m, conn.GetNetworkService(), metadata.IsClient(v))
+		delV46(ctx, v.vppConn, v.m, v.vrfs, conn.GetNetworkService(), metadata.IsClient(v))
 		delTableFromMetadataV46(ctx, metadata.IsClient(v))
 
 		return conn, err
@@ -100,7 +103,7 @@ func (v *vrfServer) Request(ctx context.Context, request *networkservice.Network
 }
 
 func (v *vrfServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
-	delV46(ctx, v.vppConn, v.m, conn.GetNetworkService(), metadata.IsClient(v))
+	delV46(ctx, v.vppConn, v.m, v.vrfs, conn.GetNetworkService(), metadata.IsClient(v))
 	_, err := next.Server(ctx).Close(ctx, conn)
 	delTableFromMetadataV46(ctx, metadata.IsClient(v))
 
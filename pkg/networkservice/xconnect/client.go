@@ -24,6 +24,7 @@ import (
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/l2xconnect"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/l3xconnect"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/srv6xconnect"
 )
 
 // NewClient - creates new xconnect client chain element to that correctly handles payload.IP and payload.Ethernet
@@ -31,5 +32,6 @@ func NewClient(vppConn api.Connection) networkservice.NetworkServiceClient {
 	return chain.NewNetworkServiceClient(
 		l2xconnect.NewClient(vppConn),
 		l3xconnect.NewClient(vppConn),
+		srv6xconnect.NewClient(vppConn),
 	)
 }
@@ -0,0 +1,145 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2bridgedomain
+
+import (
+	"context"
+	"io"
+	"net"
+	"time"
+
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/govpp/binapi/l2"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+// MacEntry - MAC address of the bridge domain MAC table
+type MacEntry struct {
+	Mac       net.HardwareAddr
+	SwIfIndex interface_types.InterfaceIndex
+	// Static - the MAC is configured rather than learned
+	Static bool
+}
+
+// MacTable - returns the MAC table of the bridge domain
+func MacTable(ctx context.Context, vppConn api.Connection, bridgeID uint32) ([]*MacEntry, error) {
+	now := time.Now()
+	client, err := l2.NewServiceClient(vppConn).L2FibTableDump(ctx, &l2.L2FibTableDump{
+		BdID: bridgeID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "vppapi L2FibTableDump returned error")
+	}
+	defer func() { _ = client.Close() }()
+
+	var entries []*MacEntry
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "vppapi L2FibTableDump returned error")
+		}
+		entries = append(entries, &MacEntry{
+			Mac:       details.Mac.ToMAC(),
+			SwIfIndex: details.SwIfIndex,
+			Static:    details.StaticMac,
+		})
+	}
+	log.FromContext(ctx).
+		WithField("bridgeID", bridgeID).
+		WithField("entries", len(entries)).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "L2FibTableDump").Debug("completed")
+	return entries, nil
+}
+
+// addArpEntries - adds the ARP termination entries of the connection IPs to the bridge domain
+func addArpEntries(ctx context.Context, vppConn api.Connection, conn *networkservice.Connection, bridgeID uint32) error {
+	if err := delArpEntries(ctx, vppConn); err != nil {
+		return err
+	}
+
+	var entries []l2.BdIPMac
+	ethernetContext := conn.GetContext().GetEthernetContext()
+	ipContext := conn.GetContext().GetIpContext()
+	for _, side := range []struct {
+		mac   string
+		ipNet []*net.IPNet
+	}{
+		{ethernetContext.GetSrcMac(), ipContext.GetSrcIPNets()},
+		{ethernetContext.GetDstMac(), ipContext.GetDstIPNets()},
+	} {
+		mac, err := net.ParseMAC(side.mac)
+		if err != nil {
+			continue
+		}
+		for _, ipNet := range side.ipNet {
+			entry := l2.BdIPMac{
+				BdID: bridgeID,
+				IP:   types.ToVppAddress(ipNet.IP),
+				Mac:  types.ToVppMacAddress(&mac),
+			}
+			if err := addDelVppArpEntry(ctx, vppConn, entry, true); err != nil {
+				storeArpEntries(ctx, false, entries)
+				return err
+			}
+			entries = append(entries, entry)
+		}
+	}
+	storeArpEntries(ctx, false, entries)
+	return nil
+}
+
+// delArpEntries - deletes the ARP termination entries of the connection
+func delArpEntries(ctx context.Context, vppConn api.Connection) error {
+	entries, ok := loadAndDeleteArpEntries(ctx, false)
+	if !ok {
+		return nil
+	}
+	for _, entry := range entries {
+		if err := addDelVppArpEntry(ctx, vppConn, entry, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addDelVppArpEntry(ctx context.Context, vppConn api.Connection, entry l2.BdIPMac, isAdd bool) error {
+	now := time.Now()
+	if _, err := l2.NewServiceClient(vppConn).BdIPMacAddDel(ctx, &l2.BdIPMacAddDel{
+		IsAdd: isAdd,
+		Entry: entry,
+	}); err != nil {
+		return errors.Wrap(err, "vppapi BdIPMacAddDel returned error")
+	}
+	log.FromContext(ctx).
+		WithField("bridgeID", entry.BdID).
+		WithField("ip", entry.IP).
+		WithField("mac", entry.Mac).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "BdIPMacAddDel").Debug("completed")
+	return nil
+}
@@ -40,6 +40,8 @@ type bridgeDomain struct {
 
 	// attached interfaces
 	attached map[interface_types.InterfaceIndex]struct{}
+
+	cfg *Config
 }
 
 type bridgeDomainKey struct {
@@ -47,7 +49,7 @@ type bridgeDomainKey struct {
 	clientIfIndex interface_types.InterfaceIndex
 }
 
-func addBridgeDomain(ctx context.Context, vppConn api.Connection, bridges *genericsync.Map[bridgeDomainKey, *bridgeDomain], vlanID uint32) error {
+func addBridgeDomain(ctx context.Context, vppConn api.Connection, bridges *genericsync.Map[bridgeDomainKey, *bridgeDomain], vlanID uint32, cfg *Config) error {
 	clientIfIndex, ok := ifindex.Load(ctx, true)
 	if !ok {
 		return nil
@@ -64,16 +66,20 @@ func addBridgeDomain(ctx context.Context, vppConn api.Connection, bridges *gener
 	}
 	l2Bridge, ok := bridges.Load(key)
 	if !ok {
-		bridgeID, err := addDelVppBridgeDomain(ctx, vppConn, ^uint32(0), true)
+		bridgeID, err := CreateBridgeDomain(ctx, vppConn, cfg)
 		if err != nil {
 			return err
 		}
 		l2Bridge = &bridgeDomain{
 			id:       bridgeID,
 			attached: make(map[interface_types.InterfaceIndex]struct{}),
+			cfg:      cfg,
 		}
 		bridges.Store(key, l2Bridge)
+	} else if *l2Bridge.cfg != *cfg {
+		return errors.Errorf("bridge domain %d of vlan %d has another config: %+v, requested: %+v", l2Bridge.id, vlanID, *l2Bridge.cfg, *cfg)
 	}
+	store(ctx, false, l2Bridge.id)
 	if _, ok = l2Bridge.attached[serverIfIndex]; !ok {
 		err := addDelVppInterfaceBridgeDomain(ctx, vppConn, serverIfIndex, l2Bridge.id, 1, true)
 		if err != nil {
@@ -112,6 +118,7 @@ func delBridgeDomain(ctx context.Context, vppConn api.Connection, bridges *gener
 				delete(l2Bridge.attached, serverIfIndex)
 			}
 		}
+		_, _ = loadAndDelete(ctx, false)
 		if len(l2Bridge.attached) == 1 {
 			// last interface -> delete the bridge and the sub-interface also
 			if _, ok = l2Bridge.attached[clientIfIndex]; ok {
@@ -124,7 +131,7 @@ func delBridgeDomain(ctx context.Context, vppConn api.Connection, bridges *gener
 					return err
 				}
 				delete(l2Bridge.attached, clientIfIndex)
-				_, err = addDelVppBridgeDomain(ctx, vppConn, l2Bridge.id, false)
+				err = DeleteBridgeDomain(ctx, vppConn, l2Bridge.id, l2Bridge.cfg)
 				if err != nil {
 					return err
 				}
@@ -137,15 +144,39 @@ func delBridgeDomain(ctx context.Context, vppConn api.Connection, bridges *gener
 	return nil
 }
 
-func addDelVppBridgeDomain(ctx context.Context, vppConn api.Connection, bridgeID uint32, isAdd bool) (uint32, error) {
+// CreateBridgeDomain - creates a bridge domain with the config, returns its ID
+func CreateBridgeDomain(ctx context.Context, vppConn api.Connection, cfg *Config) (uint32, error) {
+	bridgeID, err := addDelVppBridgeDomain(ctx, vppConn, ^uint32(0), cfg, true)
+	if err != nil {
+		return 0, err
+	}
+	if err = setVppLearnLimit(ctx, vppConn, bridgeID, cfg); err != nil {
+		if _, delErr := addDelVppBridgeDomain(ctx, vppConn, bridgeID, cfg, false); delErr != nil {
+			err = errors.Wrapf(err, "failed to delete the bridge domain: %s", delErr.Error())
+		}
+		return 0, err
+	}
+	return bridgeID, nil
+}
+
+// DeleteBridgeDomain - deletes the bridge domain created with CreateBridgeDomain
+func DeleteBridgeDomain(ctx context.Context, vppConn api.Connection, bridgeID uint32, cfg *Config) error {
+	_, err := addDelVppBridgeDomain(ctx, vppConn, bridgeID, cfg, false)
+	return err
+}
+
+func addDelVppBridgeDomain(ctx context.Context, vppConn api.Connection, bridgeID uint32, cfg *Config, isAdd bool) (uint32, error) {
 	now := time.Now()
 	bridgeDomainAddDelV2 := &l2.BridgeDomainAddDelV2{
 		IsAdd:   isAdd,
 		BdID:    bridgeID,
-		Flood:   true,
-		Forward: true,
-		Learn:   true,
-		UuFlood: true,
+		Flood:   cfg.Flood,
+		Forward: cfg.Forward,
+		Learn:   cfg.Learn,
+		UuFlood: cfg.UuFlood,
+		ArpTerm: cfg.ArpTerm,
+		ArpUfwd: cfg.ArpUfwd,
+		MacAge:  cfg.MacAge,
 	}
 	rsp, err := l2.NewServiceClient(vppConn).BridgeDomainAddDelV2(ctx, bridgeDomainAddDelV2)
 	if err != nil {
@@ -154,11 +185,37 @@ func addDelVppBridgeDomain(ctx context.Context, vppConn api.Connection, bridgeID
 	log.FromContext(ctx).
 		WithField("bridgeID", rsp.BdID).
 		WithField("isAdd", isAdd).
+		WithField("flood", cfg.Flood).
+		WithField("forward", cfg.Forward).
+		WithField("learn", cfg.Learn).
+		WithField("uuFlood", cfg.UuFlood).
+		WithField("arpTerm", cfg.ArpTerm).
+		WithField("macAge", cfg.MacAge).
 		WithField("duration", time.Since(now)).
 		WithField("vppapi", "BridgeDomainAddDelV2").Debug("completed")
 	return rsp.BdID, nil
 }
 
+// setVppLearnLimit - sets the MAC learn limit of the whole bridge domain
+func setVppLearnLimit(ctx context.Context, vppConn api.Connection, bridgeID uint32, cfg *Config) error {
+	if cfg.BridgeMacLearnLimit == 0 {
+		return nil
+	}
+	now := time.Now()
+	if _, err := l2.NewServiceClient(vppConn).BridgeDomainSetLearnLimit(ctx, &l2.BridgeDomainSetLearnLimit{
+		BdID:       bridgeID,
+		LearnLimit: cfg.BridgeMacLearnLimit,
+	}); err != nil {
+		return errors.Wrap(err, "vppapi BridgeDomainSetLearnLimit returned error")
+	}
+	log.FromContext(ctx).
+		WithField("bridgeID", bridgeID).
+		WithField("learnLimit", cfg.BridgeMacLearnLimit).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "BridgeDomainSetLearnLimit").Debug("completed")
+	return nil
+}
+
 func addDelVppInterfaceBridgeDomain(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, bridgeID uint32, shg uint8, isAdd bool) error {
 	now := time.Now()
 	_, err := l2.NewServiceClient(vppConn).SwInterfaceSetL2Bridge(ctx, &l2.SwInterfaceSetL2Bridge{
@@ -0,0 +1,69 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2bridgedomain
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+const viaLabel = "via"
+
+// Config - behaviour of the bridge domain. A bridge domain belongs to a single config: the config of the connection
+// creating it, the connections with another config are not added to it
+type Config struct {
+	// Flood - flood the broadcast and multicast frames
+	Flood bool
+	// UuFlood - flood the frames to unknown unicast MACs
+	UuFlood bool
+	// Forward - forward the frames to the learned MACs
+	Forward bool
+	// Learn - learn the source MACs of the received frames
+	Learn bool
+	// ArpTerm - answer the ARP requests and IPv6 neighbor solicitations of the connection IPs in the bridge domain.
+	// The entries are populated from the IP context of the connections
+	ArpTerm bool
+	// ArpUfwd - forward the ARP requests of the unknown IPs, when ArpTerm is enabled
+	ArpUfwd bool
+	// MacAge - aging time of the learned MACs in minutes, 0 disables the aging
+	MacAge uint8
+	// BridgeMacLearnLimit - number of the MACs the whole bridge domain learns, 0 keeps the VPP default. VPP has no
+	// per port learn limit, so a single port can still use up the whole limit
+	BridgeMacLearnLimit uint32
+}
+
+// DefaultConfig - returns the config of the bridge domains: flood, forward, learn and uu-flood on, no MAC aging
+// and no ARP termination
+func DefaultConfig() *Config {
+	return &Config{
+		Flood:   true,
+		UuFlood: true,
+		Forward: true,
+		Learn:   true,
+	}
+}
+
+// config - returns the config of the connection bridge domain: the config of its network service, then the config of
+// its vlan domain, then the default one
+func (o *l2BridgeDomainOptions) config(conn *networkservice.Connection) *Config {
+	if cfg, ok := o.networkServiceConfigs[conn.GetNetworkService()]; ok {
+		return cfg
+	}
+	if cfg, ok := o.vlanDomainConfigs[conn.GetLabels()[viaLabel]]; ok {
+		return cfg
+	}
+	return o.defaultConfig
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2bridgedomain
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/govpp/binapi/l2"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type key struct{}
+
+// store sets the bridge domain ID stored in per Connection.Id metadata.
+func store(ctx context.Context, isClient bool, bridgeID uint32) {
+	metadata.Map(ctx, isClient).Store(key{}, bridgeID)
+}
+
+// Load returns the ID of the bridge domain of the connection stored in per Connection.Id metadata, e.g. to look at
+// its MAC table.
+// The ok result indicates whether value was found in the per Connection.Id metadata.
+func Load(ctx context.Context, isClient bool) (value uint32, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(uint32)
+	return value, ok
+}
+
+// loadAndDelete deletes the bridge domain ID stored in per Connection.Id metadata,
+// returning the previous value if any. The loaded result reports whether the key was present.
+func loadAndDelete(ctx context.Context, isClient bool) (value uint32, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(uint32)
+	return value, ok
+}
+
+type arpKey struct{}
+
+// storeArpEntries sets the ARP termination entries of the connection stored in per Connection.Id metadata.
+func storeArpEntries(ctx context.Context, isClient bool, entries []l2.BdIPMac) {
+	metadata.Map(ctx, isClient).Store(arpKey{}, entries)
+}
+
+// loadAndDeleteArpEntries deletes the ARP termination entries stored in per Connection.Id metadata,
+// returning the previous value if any. The loaded result reports whether the key was present.
+func loadAndDeleteArpEntries(ctx context.Context, isClient bool) (value []l2.BdIPMac, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(arpKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.([]l2.BdIPMac)
+	return value, ok
+}
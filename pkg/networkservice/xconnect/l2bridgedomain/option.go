@@ -0,0 +1,48 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2bridgedomain
+
+type l2BridgeDomainOptions struct {
+	defaultConfig         *Config
+	networkServiceConfigs map[string]*Config
+	vlanDomainConfigs     map[string]*Config
+}
+
+// Option is an option pattern for l2bridgedomain server
+type Option func(o *l2BridgeDomainOptions)
+
+// WithDefaultConfig sets the config of the bridge domains without a network service or vlan domain config.
+// Default: DefaultConfig()
+func WithDefaultConfig(cfg *Config) Option {
+	return func(o *l2BridgeDomainOptions) {
+		o.defaultConfig = cfg
+	}
+}
+
+// WithNetworkServiceConfig sets the config of the bridge domains of the network service
+func WithNetworkServiceConfig(networkService string, cfg *Config) Option {
+	return func(o *l2BridgeDomainOptions) {
+		o.networkServiceConfigs[networkService] = cfg
+	}
+}
+
+// WithVlanDomainConfig sets the config of the bridge domains of the vlan domain (via label)
+func WithVlanDomainConfig(domain string, cfg *Config) Option {
+	return func(o *l2BridgeDomainOptions) {
+		o.vlanDomainConfigs[domain] = cfg
+	}
+}
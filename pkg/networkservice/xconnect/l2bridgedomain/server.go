@@ -39,12 +39,23 @@ import (
 type l2BridgeDomainServer struct {
 	vppConn api.Connection
 	b       genericsync.Map[bridgeDomainKey, *bridgeDomain]
+	opts    *l2BridgeDomainOptions
 }
 
 // NewServer returns a Client chain element that will add client and server vpp interface (if present) to a dridge domain
-func NewServer(vppConn api.Connection) networkservice.NetworkServiceServer {
+func NewServer(vppConn api.Connection, options ...Option) networkservice.NetworkServiceServer {
+	opts := &l2BridgeDomainOptions{
+		defaultConfig:         DefaultConfig(),
+		networkServiceConfigs: make(map[string]*Config),
+		vlanDomainConfigs:     make(map[string]*Config),
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+
 	return &l2BridgeDomainServer{
 		vppConn: vppConn,
+		opts:    opts,
 	}
 }
 
@@ -66,7 +77,23 @@ func (v *l2BridgeDomainServer) Request(ctx context.Context, request *networkserv
 		return conn, nil
 	}
 
-	if err := addBridgeDomain(ctx, v.vppConn, &v.b, vlanID); err != nil {
+	cfg := v.opts.config(conn)
+	if err := addBridgeDomain(ctx, v.vppConn, &v.b, vlanID, cfg); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := v.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	bridgeID, ok := Load(ctx, false)
+	if !ok || !cfg.ArpTerm {
+		return conn, nil
+	}
+	if err := addArpEntries(ctx, v.vppConn, conn, bridgeID); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -85,6 +112,9 @@ func (v *l2BridgeDomainServer) Close(ctx context.Context, conn *networkservice.C
 	if !ok || conn.GetPayload() != payload.Ethernet {
 		return next.Server(ctx).Close(ctx, conn)
 	}
+	if err := delArpEntries(ctx, v.vppConn); err != nil {
+		log.FromContext(ctx).WithField("l2BridgeDomain", "server").Error("delArpEntries", err)
+	}
 	if err := delBridgeDomain(ctx, v.vppConn, &v.b, vlanID); err != nil {
 		log.FromContext(ctx).WithField("l2BridgeDomain", "server").Error("delBridgeDomain", err)
 	}
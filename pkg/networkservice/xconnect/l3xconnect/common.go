@@ -114,7 +114,7 @@ func l3xcUpdate(fromSwIfIndex, toIfIndex interface_types.InterfaceIndex, nextHop
 		if nh == nil {
 			continue
 		}
-		if isIP6 && nh.IP.To4() != nil {
+		if (nh.IP.To4() == nil) != isIP6 {
 			continue
 		}
 		proto := fib_types.FIB_API_PATH_NH_PROTO_IP4
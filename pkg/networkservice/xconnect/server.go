@@ -24,6 +24,7 @@ import (
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/l2xconnect"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/l3xconnect"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/srv6xconnect"
 )
 
 // NewServer - creates new xconnect server chain element to that correctly handles payload.IP and payload.Ethernet
@@ -31,5 +32,6 @@ func NewServer(vppConn api.Connection) networkservice.NetworkServiceServer {
 	return chain.NewNetworkServiceServer(
 		l2xconnect.NewServer(vppConn),
 		l3xconnect.NewServer(vppConn),
+		srv6xconnect.NewServer(vppConn),
 	)
 }
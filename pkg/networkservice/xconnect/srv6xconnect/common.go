@@ -0,0 +1,284 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv6xconnect
+
+import (
+	"context"
+	"net"
+	"time"
+
+	interfaces "github.com/networkservicemesh/govpp/binapi/interface"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/govpp/binapi/ip"
+	"github.com/networkservicemesh/govpp/binapi/sr"
+	"github.com/networkservicemesh/govpp/binapi/sr_types"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/srv6"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+// state - vpp configuration created for the connection
+type state struct {
+	swIfIndex interface_types.InterfaceIndex
+	segments  []*srv6.Segment
+	localSIDs []net.IP
+	steerings []*sr.SrSteeringAddDel
+	tables    []ip.IPTable
+}
+
+// segments - returns the srv6 segments of one side of the connection and the vpp interface of the other side
+func segments(ctx context.Context, conn *networkservice.Connection) ([]*srv6.Segment, interface_types.InterfaceIndex, []*net.IPNet, bool) {
+	if segments, ok := srv6.Load(ctx, true); ok {
+		swIfIndex, ok := ifindex.Load(ctx, false)
+		return segments, swIfIndex, conn.GetContext().GetIpContext().GetSrcIPNets(), ok
+	}
+	if segments, ok := srv6.Load(ctx, false); ok {
+		swIfIndex, ok := ifindex.Load(ctx, true)
+		return segments, swIfIndex, conn.GetContext().GetIpContext().GetDstIPNets(), ok
+	}
+	return nil, 0, nil, false
+}
+
+func create(ctx context.Context, vppConn api.Connection, conn *networkservice.Connection, isClient bool) error {
+	segments, swIfIndex, nextHops, ok := segments(ctx, conn)
+	if !ok {
+		return nil
+	}
+	if s, ok := load(ctx, isClient); ok {
+		if s.swIfIndex == swIfIndex && equal(s.segments, segments) {
+			return nil
+		}
+		if err := del(ctx, vppConn, isClient); err != nil {
+			return err
+		}
+	}
+
+	s := &state{
+		swIfIndex: swIfIndex,
+		segments:  segments,
+	}
+	store(ctx, isClient, s)
+
+	for _, segment := range segments {
+		switch segment.Behavior {
+		case sr_types.SR_BEHAVIOR_API_DX2:
+			if err := addLocalSID(ctx, vppConn, s, segment, nil); err != nil {
+				return err
+			}
+			if err := addSteering(ctx, vppConn, s, &sr.SrSteeringAddDel{
+				BsidAddr:    types.ToVppIP6Address(segment.BSID),
+				SwIfIndex:   swIfIndex,
+				TrafficType: sr_types.SR_STEER_API_L2,
+			}); err != nil {
+				return err
+			}
+		case sr_types.SR_BEHAVIOR_API_DX6, sr_types.SR_BEHAVIOR_API_DX4:
+			isIPv6 := segment.Behavior == sr_types.SR_BEHAVIOR_API_DX6
+			nextHop := firstIP(nextHops, isIPv6)
+			if nextHop == nil {
+				return errors.Errorf("no next hop of the address family of the %v local SID %s", segment.Behavior, segment.LocalSID)
+			}
+			if err := addLocalSID(ctx, vppConn, s, segment, nextHop); err != nil {
+				return err
+			}
+			tableID, err := addTable(ctx, vppConn, s, swIfIndex, isIPv6)
+			if err != nil {
+				return err
+			}
+			steering := &sr.SrSteeringAddDel{
+				BsidAddr:    types.ToVppIP6Address(segment.BSID),
+				TableID:     tableID,
+				Prefix:      types.ToVppPrefix(&net.IPNet{IP: net.IPv4zero.To4(), Mask: net.CIDRMask(0, net.IPv4len*8)}),
+				TrafficType: sr_types.SR_STEER_API_IPV4,
+			}
+			if isIPv6 {
+				steering.Prefix = types.ToVppPrefix(&net.IPNet{IP: net.IPv6zero, Mask: net.CIDRMask(0, net.IPv6len*8)})
+				steering.TrafficType = sr_types.SR_STEER_API_IPV6
+			}
+			if err := addSteering(ctx, vppConn, s, steering); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func addLocalSID(ctx context.Context, vppConn api.Connection, s *state, segment *srv6.Segment, nextHop net.IP) error {
+	now := time.Now()
+	localSIDAdd := &sr.SrLocalsidAddDel{
+		Localsid:  types.ToVppIP6Address(segment.LocalSID),
+		Behavior:  segment.Behavior,
+		SwIfIndex: s.swIfIndex,
+	}
+	if nextHop != nil {
+		localSIDAdd.NhAddr = types.ToVppAddress(nextHop)
+	}
+	if _, err := sr.NewServiceClient(vppConn).SrLocalsidAddDel(ctx, localSIDAdd); err != nil {
+		return errors.Wrap(err, "vppapi SrLocalsidAddDel returned error")
+	}
+	log.FromContext(ctx).
+		WithField("Localsid", segment.LocalSID).
+		WithField("Behavior", segment.Behavior).
+		WithField("SwIfIndex", s.swIfIndex).
+		WithField("NhAddr", nextHop).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SrLocalsidAddDel").Debug("completed")
+	s.localSIDs = append(s.localSIDs, segment.LocalSID)
+	return nil
+}
+
+func addSteering(ctx context.Context, vppConn api.Connection, s *state, steering *sr.SrSteeringAddDel) error {
+	now := time.Now()
+	if _, err := sr.NewServiceClient(vppConn).SrSteeringAddDel(ctx, steering); err != nil {
+		return errors.Wrap(err, "vppapi SrSteeringAddDel returned error")
+	}
+	log.FromContext(ctx).
+		WithField("BsidAddr", steering.BsidAddr).
+		WithField("TrafficType", steering.TrafficType).
+		WithField("SwIfIndex", steering.SwIfIndex).
+		WithField("TableID", steering.TableID).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SrSteeringAddDel").Debug("completed")
+	s.steerings = append(s.steerings, steering)
+	return nil
+}
+
+// addTable - allocates a table the interface is moved to, so that the traffic it receives can be steered
+// into the SR policy by the default route
+func addTable(ctx context.Context, vppConn api.Connection, s *state, swIfIndex interface_types.InterfaceIndex, isIPv6 bool) (uint32, error) {
+	now := time.Now()
+	reply, err := ip.NewServiceClient(vppConn).IPTableAllocate(ctx, &ip.IPTableAllocate{
+		Table: ip.IPTable{
+			TableID: ^uint32(0),
+			IsIP6:   isIPv6,
+		},
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "vppapi IPTableAllocate returned error")
+	}
+	log.FromContext(ctx).
+		WithField("vrfID", reply.Table.TableID).
+		WithField("isIP6", isIPv6).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IPTableAllocate").Debug("completed")
+	s.tables = append(s.tables, reply.Table)
+
+	now = time.Now()
+	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetTable(ctx, &interfaces.SwInterfaceSetTable{
+		SwIfIndex: swIfIndex,
+		IsIPv6:    isIPv6,
+		VrfID:     reply.Table.TableID,
+	}); err != nil {
+		return 0, errors.Wrap(err, "vppapi SwInterfaceSetTable returned error")
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("vrfID", reply.Table.TableID).
+		WithField("isIPv6", isIPv6).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetTable").Debug("completed")
+	return reply.Table.TableID, nil
+}
+
+func del(ctx context.Context, vppConn api.Connection, isClient bool) error {
+	s, ok := loadAndDelete(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	for _, steering := range s.steerings {
+		steering.IsDel = true
+		now := time.Now()
+		if _, err := sr.NewServiceClient(vppConn).SrSteeringAddDel(ctx, steering); err != nil {
+			return errors.Wrap(err, "vppapi SrSteeringAddDel returned error")
+		}
+		log.FromContext(ctx).
+			WithField("BsidAddr", steering.BsidAddr).
+			WithField("IsDel", steering.IsDel).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "SrSteeringAddDel").Debug("completed")
+	}
+	for _, localSID := range s.localSIDs {
+		now := time.Now()
+		if _, err := sr.NewServiceClient(vppConn).SrLocalsidAddDel(ctx, &sr.SrLocalsidAddDel{
+			IsDel:    true,
+			Localsid: types.ToVppIP6Address(localSID),
+		}); err != nil {
+			return errors.Wrap(err, "vppapi SrLocalsidAddDel returned error")
+		}
+		log.FromContext(ctx).
+			WithField("Localsid", localSID).
+			WithField("IsDel", true).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "SrLocalsidAddDel").Debug("completed")
+	}
+	for _, table := range s.tables {
+		now := time.Now()
+		if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetTable(ctx, &interfaces.SwInterfaceSetTable{
+			SwIfIndex: s.swIfIndex,
+			IsIPv6:    table.IsIP6,
+		}); err != nil {
+			return errors.Wrap(err, "vppapi SwInterfaceSetTable returned error")
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", s.swIfIndex).
+			WithField("isIPv6", table.IsIP6).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "SwInterfaceSetTable").Debug("completed")
+
+		now = time.Now()
+		if _, err := ip.NewServiceClient(vppConn).IPTableAddDel(ctx, &ip.IPTableAddDel{
+			IsAdd: false,
+			Table: table,
+		}); err != nil {
+			return errors.Wrap(err, "vppapi IPTableAddDel returned error")
+		}
+		log.FromContext(ctx).
+			WithField("isAdd", false).
+			WithField("vrfID", table.TableID).
+			WithField("isIP6", table.IsIP6).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "IPTableAddDel").Debug("completed")
+	}
+	return nil
+}
+
+func firstIP(ipNets []*net.IPNet, isIPv6 bool) net.IP {
+	for _, ipNet := range ipNets {
+		if (ipNet.IP.To4() == nil) == isIPv6 {
+			return ipNet.IP
+		}
+	}
+	return nil
+}
+
+func equal(a, b []*srv6.Segment) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain2device
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/fs"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// FileProvider - domain to device mapping read from a yaml file of "domain: device" entries
+type FileProvider struct {
+	path          string
+	domain2Device Map
+	mu            sync.RWMutex
+}
+
+// NewFileProvider - returns a provider reading the mapping from the file
+func NewFileProvider(path string) (*FileProvider, error) {
+	p := &FileProvider{
+		path: path,
+	}
+	if err := p.Reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Device - returns the host device of the vlan domain
+func (p *FileProvider) Device(domain string) (device string, ok bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.domain2Device.Device(domain)
+}
+
+// Reload - reads the mapping from the file again
+func (p *FileProvider) Reload() error {
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", p.path)
+	}
+	return p.update(data)
+}
+
+// Watch - reloads the mapping on every change of the file until the ctx is done. The mapping is cleared if the file
+// is removed
+func (p *FileProvider) Watch(ctx context.Context) {
+	logger := log.FromContext(ctx).WithField("domain2device", p.path)
+	ch := fs.WatchFile(ctx, p.path)
+	go func() {
+		for data := range ch {
+			if err := p.update(data); err != nil {
+				logger.Errorf("failed to reload the domain to device mapping: %s", err.Error())
+				continue
+			}
+			logger.Debug("domain to device mapping reloaded")
+		}
+	}()
+}
+
+func (p *FileProvider) update(data []byte) error {
+	domain2Device := make(Map)
+	if err := yaml.Unmarshal(data, &domain2Device); err != nil {
+		return errors.Wrapf(err, "failed to parse %s", p.path)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.domain2Device = domain2Device
+	return nil
+}
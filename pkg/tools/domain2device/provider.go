@@ -0,0 +1,31 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain2device
+
+// Provider - returns the host device of the vlan domain
+type Provider interface {
+	Device(domain string) (device string, ok bool)
+}
+
+// Map - static domain to device mapping
+type Map map[string]string
+
+// Device - returns the host device of the vlan domain
+func (m Map) Device(domain string) (device string, ok bool) {
+	device, ok = m[domain]
+	return device, ok
+}
@@ -17,7 +17,8 @@
 //go:build linux
 // +build linux
 
-// Package ethtool provides some utilities for disabling checksum offload using ethtool
+// Package ethtool provides utilities for querying and setting ethtool offload features, ring sizes and channel
+// counts of the network interfaces
 package ethtool
 
 import (
@@ -26,15 +27,14 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nshandle"
 )
 
 const (
 	siocEthtool = 0x8946 // linux/sockios.h
 
-	ethtoolSRxCsum = 0x00000015 // linux/ethtoolDisableOffload.h
-
-	ethtoolSTxCsum = 0x00000017 // linux/ethtoolDisableOffload.h
-
 	maxIfNameSize = 16 // linux/if.h
 )
 
@@ -44,54 +44,77 @@ type ifreq struct {
 	Data uintptr
 }
 
-// linux/ethtoolDisableOffload.h 'struct ethtool_value'
+// linux/ethtool.h 'struct ethtool_value'
 type ethtoolValue struct {
 	Cmd  uint32
 	Data uint32
 }
 
-// ethtoolDisableOffload executes Linux ethtoolDisableOffload syscall.
-func ethtoolDisableOffload(iface string, cmd uint32) (err error) {
-	if len(iface)+1 > maxIfNameSize {
-		return errors.Errorf("interface name is too long")
-	}
+// Handle - ethtool handle bound to a net NS
+type Handle struct {
+	socket int
+}
+
+// NewHandle - returns a new Handle for the interfaces in the current net NS
+func NewHandle() (*Handle, error) {
 	socket, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_DGRAM, 0)
 	if err != nil {
-		return errors.Wrap(err, "failed to create socket")
+		return nil, errors.Wrap(err, "failed to create socket")
+	}
+	return &Handle{socket: socket}, nil
+}
+
+// NewHandleAt - returns a new Handle for the interfaces in the target net NS
+func NewHandleAt(target netns.NsHandle) (*Handle, error) {
+	current, err := nshandle.Current()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = current.Close() }()
+
+	var h *Handle
+	if err := nshandle.RunIn(current, target, func() (err error) {
+		h, err = NewHandle()
+		return err
+	}); err != nil {
+		return nil, err
 	}
-	defer func() { _ = syscall.Close(socket) }()
+	return h, nil
+}
+
+// Close - closes the Handle
+func (h *Handle) Close() error {
+	return syscall.Close(h.socket)
+}
 
-	// prepare ethtoolDisableOffload request
-	value := ethtoolValue{cmd, 0}
-	request := ifreq{Data: uintptr(unsafe.Pointer(&value))} // #nosec
+// ioctl executes Linux ethtool ioctl system call, data must point to the ethtool command structure
+func (h *Handle) ioctl(iface string, data unsafe.Pointer) error {
+	if len(iface)+1 > maxIfNameSize {
+		return errors.Errorf("interface name is too long: %s", iface)
+	}
+	request := ifreq{Data: uintptr(data)}
 	copy(request.Name[:], iface)
 
-	// ioctl system call
-	_, _, errno := syscall.RawSyscall(syscall.SYS_IOCTL, uintptr(socket), uintptr(siocEthtool),
+	_, _, errno := syscall.RawSyscall(syscall.SYS_IOCTL, uintptr(h.socket), uintptr(siocEthtool),
 		uintptr(unsafe.Pointer(&request))) // #nosec
 	if errno != 0 {
-		return errors.Wrap(errno, "failed to execute ioctl system call")
+		return errors.Wrapf(errno, "failed to execute ethtool ioctl system call for %s", iface)
 	}
 	return nil
 }
 
 // DisableVethChkSumOffload - disables ChkSumOffload for Veth
 func DisableVethChkSumOffload(veth *netlink.Veth) error {
-	err := ethtoolDisableOffload(veth.LinkAttrs.Name, ethtoolSTxCsum)
-	if err != nil {
-		return err
-	}
-	err = ethtoolDisableOffload(veth.LinkAttrs.Name, ethtoolSRxCsum)
-	if err != nil {
-		return err
-	}
-	err = ethtoolDisableOffload(veth.PeerName, ethtoolSTxCsum)
+	h, err := NewHandle()
 	if err != nil {
 		return err
 	}
-	err = ethtoolDisableOffload(veth.PeerName, ethtoolSRxCsum)
-	if err != nil {
-		return err
+	defer func() { _ = h.Close() }()
+
+	for _, iface := range []string{veth.LinkAttrs.Name, veth.PeerName} {
+		if err := h.Apply(iface, DisableChkSumOffload); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -0,0 +1,94 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ethtool
+
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// linux/ethtool.h ETHTOOL_G* and ETHTOOL_S* commands
+var featureCmds = map[Feature]struct{ get, set uint32 }{
+	RxCsum: {get: 0x00000014, set: 0x00000015},
+	TxCsum: {get: 0x00000016, set: 0x00000017},
+	SG:     {get: 0x00000018, set: 0x00000019},
+	TSO:    {get: 0x0000001e, set: 0x0000001f},
+	GSO:    {get: 0x00000023, set: 0x00000024},
+	GRO:    {get: 0x0000002b, set: 0x0000002c},
+}
+
+// featureOrder - features in the order they depend on each other: SG needs TX checksumming,
+// TSO and GSO need SG
+var featureOrder = []Feature{RxCsum, TxCsum, SG, TSO, GSO, GRO}
+
+// Feature - returns if the feature is enabled on the interface
+func (h *Handle) Feature(iface string, feature Feature) (bool, error) {
+	cmds, ok := featureCmds[feature]
+	if !ok {
+		return false, errors.Errorf("unsupported ethtool feature: %s", feature)
+	}
+	value := ethtoolValue{Cmd: cmds.get}
+	if err := h.ioctl(iface, unsafe.Pointer(&value)); err != nil { // #nosec
+		return false, errors.Wrapf(err, "failed to get %s", feature)
+	}
+	return value.Data != 0, nil
+}
+
+// SetFeature - enables or disables the feature on the interface
+func (h *Handle) SetFeature(iface string, feature Feature, enabled bool) error {
+	cmds, ok := featureCmds[feature]
+	if !ok {
+		return errors.Errorf("unsupported ethtool feature: %s", feature)
+	}
+	value := ethtoolValue{Cmd: cmds.set}
+	if enabled {
+		value.Data = 1
+	}
+	if err := h.ioctl(iface, unsafe.Pointer(&value)); err != nil { // #nosec
+		return errors.Wrapf(err, "failed to set %s to %v", feature, enabled)
+	}
+	return nil
+}
+
+// Apply - sets the profile features on the interface. The features are disabled before their dependencies
+// and enabled after them.
+func (h *Handle) Apply(iface string, profile Profile) error {
+	for feature := range profile {
+		if _, ok := featureCmds[feature]; !ok {
+			return errors.Errorf("unsupported ethtool feature: %s", feature)
+		}
+	}
+	for i := len(featureOrder) - 1; i >= 0; i-- {
+		if enabled, ok := profile[featureOrder[i]]; ok && !enabled {
+			if err := h.SetFeature(iface, featureOrder[i], false); err != nil {
+				return err
+			}
+		}
+	}
+	for _, feature := range featureOrder {
+		if enabled, ok := profile[feature]; ok && enabled {
+			if err := h.SetFeature(iface, feature, true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
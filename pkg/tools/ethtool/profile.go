@@ -0,0 +1,44 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethtool
+
+// Feature - ethtool offload feature
+type Feature string
+
+const (
+	// RxCsum - rx checksum offload
+	RxCsum Feature = "rx-checksum"
+	// TxCsum - tx checksum offload
+	TxCsum Feature = "tx-checksum"
+	// SG - scatter-gather
+	SG Feature = "scatter-gather"
+	// TSO - TCP segmentation offload
+	TSO Feature = "tcp-segmentation-offload"
+	// GSO - generic segmentation offload
+	GSO Feature = "generic-segmentation-offload"
+	// GRO - generic receive offload
+	GRO Feature = "generic-receive-offload"
+)
+
+// Profile - offload features to enable (true) or disable (false), the features not in the Profile are left as is
+type Profile map[Feature]bool
+
+// DisableChkSumOffload - Profile disabling rx and tx checksum offload
+var DisableChkSumOffload = Profile{
+	RxCsum: false,
+	TxCsum: false,
+}
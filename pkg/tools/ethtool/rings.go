@@ -0,0 +1,164 @@
+// Copyright (c) 2024 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ethtool
+
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ethtoolGRingParam = 0x00000010 // linux/ethtool.h
+	ethtoolSRingParam = 0x00000011 // linux/ethtool.h
+	ethtoolGChannels  = 0x0000003c // linux/ethtool.h
+	ethtoolSChannels  = 0x0000003d // linux/ethtool.h
+)
+
+// linux/ethtool.h 'struct ethtool_ringparam'
+type ethtoolRingParam struct {
+	Cmd               uint32
+	RxMaxPending      uint32
+	RxMiniMaxPending  uint32
+	RxJumboMaxPending uint32
+	TxMaxPending      uint32
+	RxPending         uint32
+	RxMiniPending     uint32
+	RxJumboPending    uint32
+	TxPending         uint32
+}
+
+// linux/ethtool.h 'struct ethtool_channels'
+type ethtoolChannels struct {
+	Cmd           uint32
+	MaxRx         uint32
+	MaxTx         uint32
+	MaxOther      uint32
+	MaxCombined   uint32
+	RxCount       uint32
+	TxCount       uint32
+	OtherCount    uint32
+	CombinedCount uint32
+}
+
+// Rings - interface ring sizes
+type Rings struct {
+	RxMax uint32
+	TxMax uint32
+	Rx    uint32
+	Tx    uint32
+}
+
+// Channels - interface channel counts
+type Channels struct {
+	MaxRx       uint32
+	MaxTx       uint32
+	MaxCombined uint32
+	Rx          uint32
+	Tx          uint32
+	Combined    uint32
+}
+
+func (h *Handle) ringParam(iface string) (*ethtoolRingParam, error) {
+	param := &ethtoolRingParam{Cmd: ethtoolGRingParam}
+	if err := h.ioctl(iface, unsafe.Pointer(param)); err != nil { // #nosec
+		return nil, errors.Wrap(err, "failed to get ring sizes")
+	}
+	return param, nil
+}
+
+// Rings - returns the current and the maximum ring sizes of the interface
+func (h *Handle) Rings(iface string) (*Rings, error) {
+	param, err := h.ringParam(iface)
+	if err != nil {
+		return nil, err
+	}
+	return &Rings{
+		RxMax: param.RxMaxPending,
+		TxMax: param.TxMaxPending,
+		Rx:    param.RxPending,
+		Tx:    param.TxPending,
+	}, nil
+}
+
+// SetRings - sets the rx and tx ring sizes of the interface, zero sizes are left as is
+func (h *Handle) SetRings(iface string, rx, tx uint32) error {
+	param, err := h.ringParam(iface)
+	if err != nil {
+		return err
+	}
+	if rx != 0 {
+		param.RxPending = rx
+	}
+	if tx != 0 {
+		param.TxPending = tx
+	}
+	param.Cmd = ethtoolSRingParam
+	if err := h.ioctl(iface, unsafe.Pointer(param)); err != nil { // #nosec
+		return errors.Wrapf(err, "failed to set ring sizes rx %d tx %d", rx, tx)
+	}
+	return nil
+}
+
+func (h *Handle) channels(iface string) (*ethtoolChannels, error) {
+	channels := &ethtoolChannels{Cmd: ethtoolGChannels}
+	if err := h.ioctl(iface, unsafe.Pointer(channels)); err != nil { // #nosec
+		return nil, errors.Wrap(err, "failed to get channels")
+	}
+	return channels, nil
+}
+
+// Channels - returns the current and the maximum channel counts of the interface
+func (h *Handle) Channels(iface string) (*Channels, error) {
+	channels, err := h.channels(iface)
+	if err != nil {
+		return nil, err
+	}
+	return &Channels{
+		MaxRx:       channels.MaxRx,
+		MaxTx:       channels.MaxTx,
+		MaxCombined: channels.MaxCombined,
+		Rx:          channels.RxCount,
+		Tx:          channels.TxCount,
+		Combined:    channels.CombinedCount,
+	}, nil
+}
+
+// SetChannels - sets the rx, tx and combined channel counts of the interface, zero counts are left as is
+func (h *Handle) SetChannels(iface string, rx, tx, combined uint32) error {
+	channels, err := h.channels(iface)
+	if err != nil {
+		return err
+	}
+	if rx != 0 {
+		channels.RxCount = rx
+	}
+	if tx != 0 {
+		channels.TxCount = tx
+	}
+	if combined != 0 {
+		channels.CombinedCount = combined
+	}
+	channels.Cmd = ethtoolSChannels
+	if err := h.ioctl(iface, unsafe.Pointer(channels)); err != nil { // #nosec
+		return errors.Wrapf(err, "failed to set channels rx %d tx %d combined %d", rx, tx, combined)
+	}
+	return nil
+}
@@ -39,6 +39,20 @@ func ToVppAddress(addr net.IP) ip_types.Address {
 	return a
 }
 
+// ToVppIP4Address - converts IPv4 addr to ip_types.IP4Address
+func ToVppIP4Address(addr net.IP) ip_types.IP4Address {
+	a := ip_types.IP4Address{}
+	copy(a[:], addr.To4())
+	return a
+}
+
+// ToVppIP6Address - converts IPv6 addr to ip_types.IP6Address
+func ToVppIP6Address(addr net.IP) ip_types.IP6Address {
+	a := ip_types.IP6Address{}
+	copy(a[:], addr.To16())
+	return a
+}
+
 // ToVppAddressWithPrefix - converts prefix to ip_types.AddressWithPrefix
 func ToVppAddressWithPrefix(prefix *net.IPNet) ip_types.AddressWithPrefix {
 	return ip_types.AddressWithPrefix(ToVppPrefix(prefix))